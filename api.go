@@ -34,7 +34,7 @@ func (s *Scraper) RequestAPI(req *http.Request, target interface{}) error {
 	req.Header.Set("X-Guest-Token", s.guestToken)
 
 	// use cookie
-	if len(s.cookie) > 0 && len(s.xCsrfToken) > 0 {
+	if s.IsLoggedIn() {
 		req.Header.Set("Cookie", s.cookie)
 		req.Header.Set("x-csrf-token", s.xCsrfToken)
 	}
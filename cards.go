@@ -0,0 +1,214 @@
+package twitterscraper
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LinkPreview is a structured card of the link shared in the tweet.
+// Kind is one of summary, summary_large_image, player, app or unified_card.
+type LinkPreview struct {
+	Kind         string
+	Title        string
+	Description  string
+	Domain       string
+	VanityURL    string
+	URL          string
+	Images       []CardImage
+	PlayerURL    string
+	PlayerWidth  int
+	PlayerHeight int
+	Site         Profile
+	Creator      Profile
+	Components   []CardComponent
+}
+
+// CardImage of the link preview, the same image is available at multiple sizes.
+type CardImage struct {
+	Name   string
+	URL    string
+	Width  int
+	Height int
+	Alt    string
+}
+
+// CardComponent of the unified card, like details, media or button.
+type CardComponent struct {
+	Type        string
+	Title       string
+	Subtitle    string
+	MediaURL    string
+	Destination CardDestination
+}
+
+// CardDestination where the unified card component leads to.
+type CardDestination struct {
+	Type   string
+	URL    string
+	Vanity string
+	AppID  string
+}
+
+// unifiedCard JSON object encoded in the unified_card binding value
+type unifiedCard struct {
+	Type             string   `json:"type"`
+	Components       []string `json:"components"`
+	ComponentObjects map[string]struct {
+		Type string `json:"type"`
+		Data struct {
+			Title struct {
+				Content string `json:"content"`
+			} `json:"title"`
+			Subtitle struct {
+				Content string `json:"content"`
+			} `json:"subtitle"`
+			Destination string `json:"destination"`
+			ID          string `json:"id"`
+		} `json:"data"`
+	} `json:"component_objects"`
+	DestinationObjects map[string]struct {
+		Type string `json:"type"`
+		Data struct {
+			URLData struct {
+				URL    string `json:"url"`
+				Vanity string `json:"vanity"`
+			} `json:"url_data"`
+			AppID string `json:"app_id"`
+		} `json:"data"`
+	} `json:"destination_objects"`
+	MediaEntities map[string]struct {
+		MediaURLHttps string `json:"media_url_https"`
+		ExtAltText    string `json:"ext_alt_text"`
+		OriginalInfo  struct {
+			Width  int `json:"width"`
+			Height int `json:"height"`
+		} `json:"original_info"`
+	} `json:"media_entities"`
+}
+
+// parseLinkPreview returns link preview of the card, nil if card is not a link preview.
+// Short links of the card are replaced by expanded URLs found in urls.
+func parseLinkPreview(card Card, urls map[string]string) *LinkPreview {
+	kind := card.Legacy.Name
+	if i := strings.LastIndex(kind, ":"); i >= 0 {
+		kind = kind[i+1:]
+	}
+	switch kind {
+	case "summary", "summary_large_image", "player", "app", "unified_card":
+	default:
+		return nil
+	}
+
+	preview := &LinkPreview{
+		Kind:        kind,
+		Title:       card.bindingValue("title"),
+		Description: card.bindingValue("description"),
+		Domain:      card.bindingValue("domain"),
+		VanityURL:   card.bindingValue("vanity_url"),
+		URL:         card.bindingValue("card_url"),
+		PlayerURL:   card.bindingValue("player_url"),
+	}
+	preview.PlayerWidth, _ = strconv.Atoi(card.bindingValue("player_width"))
+	preview.PlayerHeight, _ = strconv.Atoi(card.bindingValue("player_height"))
+	if preview.URL == "" {
+		preview.URL = card.Legacy.URL
+	}
+	if expanded, ok := urls[preview.URL]; ok {
+		preview.URL = expanded
+	}
+	users := make(map[string]UserResult)
+	for _, ref := range card.Legacy.UserRefsResults {
+		users[ref.Result.RestId] = ref.Result
+	}
+
+	for _, v := range card.Legacy.BindingValues {
+		switch v.Value.Type {
+		case "IMAGE":
+			preview.Images = append(preview.Images, CardImage{
+				Name:   v.Key,
+				URL:    v.Value.ImageValue.URL,
+				Width:  v.Value.ImageValue.Width,
+				Height: v.Value.ImageValue.Height,
+				Alt:    v.Value.ImageValue.Alt,
+			})
+		case "USER":
+			user, ok := users[v.Value.UserValue.IDStr]
+			if !ok {
+				continue
+			}
+			switch v.Key {
+			case "site":
+				preview.Site = parseProfile(user)
+			case "creator":
+				preview.Creator = parseProfile(user)
+			}
+		}
+	}
+
+	if kind == "unified_card" {
+		var unified unifiedCard
+		if err := json.Unmarshal([]byte(card.bindingValue("unified_card")), &unified); err == nil {
+			preview.parseUnifiedCard(unified)
+		}
+	}
+
+	if preview.Domain == "" {
+		preview.Domain = preview.VanityURL
+	}
+
+	sort.SliceStable(preview.Images, func(i, j int) bool {
+		return preview.Images[i].Width < preview.Images[j].Width
+	})
+
+	return preview
+}
+
+// parseUnifiedCard fills preview from components of the unified card,
+// the first destination of components is the target URL
+func (preview *LinkPreview) parseUnifiedCard(unified unifiedCard) {
+	hasTarget := false
+	for _, name := range unified.Components {
+		object, ok := unified.ComponentObjects[name]
+		if !ok {
+			continue
+		}
+
+		component := CardComponent{
+			Type:     object.Type,
+			Title:    object.Data.Title.Content,
+			Subtitle: object.Data.Subtitle.Content,
+		}
+		if destination, ok := unified.DestinationObjects[object.Data.Destination]; ok {
+			component.Destination = CardDestination{
+				Type:   destination.Type,
+				URL:    destination.Data.URLData.URL,
+				Vanity: destination.Data.URLData.Vanity,
+				AppID:  destination.Data.AppID,
+			}
+		}
+		if media, ok := unified.MediaEntities[object.Data.ID]; ok {
+			component.MediaURL = media.MediaURLHttps
+			preview.Images = append(preview.Images, CardImage{
+				Name:   name,
+				URL:    media.MediaURLHttps,
+				Width:  media.OriginalInfo.Width,
+				Height: media.OriginalInfo.Height,
+				Alt:    media.ExtAltText,
+			})
+		}
+
+		if preview.Title == "" && component.Title != "" {
+			preview.Title = component.Title
+			preview.Domain = component.Subtitle
+		}
+		if !hasTarget && component.Destination.URL != "" {
+			preview.URL = component.Destination.URL
+			preview.VanityURL = component.Destination.Vanity
+			hasTarget = true
+		}
+
+		preview.Components = append(preview.Components, component)
+	}
+}
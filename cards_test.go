@@ -0,0 +1,54 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+
+	twitterscraper "github.com/n0madic/twitter-scraper"
+)
+
+func TestTweetLinkPreviewFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	scraper.WithCookie("auth_token=fixture; ct0=fixture").WithXCsrfToken("fixture")
+	var tweets []twitterscraper.Tweet
+	for tweet := range scraper.GetBookmarks(context.Background(), 10) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		tweets = append(tweets, tweet.Tweet)
+	}
+	if len(tweets) != 2 {
+		t.Fatalf("Expected 2 tweets, got %d", len(tweets))
+	}
+
+	preview := tweets[0].LinkPreview
+	if preview == nil {
+		t.Fatal("Expected LinkPreview of the tweet")
+	}
+	if preview.Kind != "summary_large_image" || preview.Title != "Announcing the Go SDK" || preview.Domain != "developer.twitter.com" {
+		t.Errorf("Unexpected LinkPreview %s %s %s", preview.Kind, preview.Title, preview.Domain)
+	}
+	if preview.URL != "https://developer.twitter.com/blog/go-sdk" {
+		t.Errorf("Expected expanded LinkPreview URL, got %s", preview.URL)
+	}
+	if len(preview.Images) != 4 || preview.Images[0].Width != 144 || preview.Images[3].Name != "summary_photo_image_original" {
+		t.Errorf("Unexpected LinkPreview images %v", preview.Images)
+	}
+	if preview.Site.Username != "TwitterDev" {
+		t.Errorf("Expected LinkPreview site TwitterDev, got %s", preview.Site.Username)
+	}
+
+	preview = tweets[1].LinkPreview
+	if preview == nil {
+		t.Fatal("Expected LinkPreview of the unified card")
+	}
+	if preview.Kind != "unified_card" || preview.Title != "Shop the new collection" || preview.URL != "https://shop.example.com/new" {
+		t.Errorf("Unexpected LinkPreview %s %s %s", preview.Kind, preview.Title, preview.URL)
+	}
+	if len(preview.Components) != 2 || preview.Components[0].Type != "media" || preview.Components[1].Destination.Type != "browser" {
+		t.Errorf("Unexpected LinkPreview components %v", preview.Components)
+	}
+	if len(preview.Images) != 1 || preview.Images[0].Alt != "New collection" {
+		t.Errorf("Unexpected LinkPreview images %v", preview.Images)
+	}
+}
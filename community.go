@@ -0,0 +1,250 @@
+package twitterscraper
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Community of twitter users.
+type Community struct {
+	ID             string
+	Name           string
+	Description    string
+	Question       string
+	Banner         string
+	Created        *time.Time
+	IsNSFW         bool
+	JoinPolicy     string
+	MemberCount    int
+	ModeratorCount int
+	Rules          []CommunityRule
+	Admin          Profile
+	Creator        Profile
+	URL            string
+}
+
+// CommunityRule of the community.
+type CommunityRule struct {
+	ID          string
+	Name        string
+	Description string
+}
+
+// CommunitySort type
+type CommunitySort int
+
+const (
+	// CommunityTop - relevance sorting
+	CommunityTop CommunitySort = iota
+	// CommunityLatest - recency sorting
+	CommunityLatest
+)
+
+type communityResult struct {
+	TypeName       string `json:"__typename"`
+	IDStr          string `json:"id_str"`
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	Question       string `json:"question"`
+	CreatedAt      int64  `json:"created_at"`
+	IsNSFW         bool   `json:"is_nsfw"`
+	JoinPolicy     string `json:"join_policy"`
+	MemberCount    int    `json:"member_count"`
+	ModeratorCount int    `json:"moderator_count"`
+	Rules          []struct {
+		RestId      string `json:"rest_id"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	} `json:"rules"`
+	AdminResults struct {
+		Result UserResult `json:"result"`
+	} `json:"admin_results"`
+	CreatorResults struct {
+		Result UserResult `json:"result"`
+	} `json:"creator_results"`
+	DefaultBannerMedia listBanner `json:"default_banner_media"`
+	CustomBannerMedia  listBanner `json:"custom_banner_media"`
+}
+
+// communityTimeline JSON object returned by the community operations
+type communityTimeline struct {
+	Errors []Err `json:"errors"`
+	Data   struct {
+		CommunityResults struct {
+			Result struct {
+				communityResult
+				RankedCommunityTimeline struct {
+					Timeline timelineV2 `json:"timeline"`
+				} `json:"ranked_community_timeline"`
+				MembersSlice struct {
+					ItemsResults []struct {
+						Result UserResult `json:"result"`
+					} `json:"items_results"`
+					SliceInfo struct {
+						NextCursor string `json:"next_cursor"`
+					} `json:"slice_info"`
+				} `json:"members_slice"`
+			} `json:"result"`
+		} `json:"communityResults"`
+	} `json:"data"`
+}
+
+// GetCommunity return community metadata.
+func (s *Scraper) GetCommunity(ctx context.Context, communityID string) (Community, error) {
+	jsn, err := s.getCommunityTimeline(ctx, "lUBKrilodgg9Nikaw3cIiA/CommunityQuery", communityID, nil)
+	if err != nil {
+		return Community{}, err
+	}
+	result := jsn.Data.CommunityResults.Result.communityResult
+	if result.TypeName != "Community" || result.IDStr == "" {
+		return Community{}, fmt.Errorf("community %s not found", communityID)
+	}
+	return parseCommunity(result), nil
+}
+
+// GetCommunityTweets returns channel with tweets of a given community in top or latest order.
+func (s *Scraper) GetCommunityTweets(ctx context.Context, communityID string, sort CommunitySort, maxTweetsNbr int) <-chan *TweetResult {
+	return getTweetTimeline(ctx, communityID, maxTweetsNbr, func(communityID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+		return s.fetchCommunityTweets(ctx, communityID, sort, maxTweetsNbr, cursor)
+	})
+}
+
+// FetchCommunityTweets gets tweets of a given community, via the Twitter frontend API.
+func (s *Scraper) FetchCommunityTweets(communityID string, sort CommunitySort, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	return s.fetchCommunityTweets(context.Background(), communityID, sort, maxTweetsNbr, cursor)
+}
+
+// fetchCommunityTweets gets tweets of a given community with the context
+func (s *Scraper) fetchCommunityTweets(ctx context.Context, communityID string, sort CommunitySort, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	if maxTweetsNbr > 50 {
+		maxTweetsNbr = 50
+	}
+
+	variables := map[string]interface{}{
+		"count":         maxTweetsNbr,
+		"rankingMode":   "Relevance",
+		"withCommunity": true,
+	}
+	if sort == CommunityLatest {
+		variables["rankingMode"] = "Recency"
+	}
+	if cursor != "" {
+		variables["cursor"] = cursor
+	}
+
+	jsn, err := s.getCommunityTimeline(ctx, "7B2AdxSuC-Er8qUr3Plm_w/CommunityTweetsTimeline", communityID, variables)
+	if err != nil {
+		return nil, "", err
+	}
+	tweets, nextCursor := jsn.Data.CommunityResults.Result.RankedCommunityTimeline.Timeline.parseTweets()
+	return s.prepareTweets(tweets), nextCursor, nil
+}
+
+// GetCommunityMembers returns channel with members of a given community.
+func (s *Scraper) GetCommunityMembers(ctx context.Context, communityID string, maxProfilesNbr int) <-chan *ProfileResult {
+	return getUserTimeline(ctx, communityID, maxProfilesNbr, func(communityID string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+		return s.fetchCommunityMembers(ctx, communityID, maxProfilesNbr, cursor)
+	})
+}
+
+// FetchCommunityMembers gets members of a given community, via the Twitter frontend API.
+func (s *Scraper) FetchCommunityMembers(communityID string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+	return s.fetchCommunityMembers(context.Background(), communityID, maxProfilesNbr, cursor)
+}
+
+// fetchCommunityMembers gets members of a given community with the context
+func (s *Scraper) fetchCommunityMembers(ctx context.Context, communityID string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+	if maxProfilesNbr > 50 {
+		maxProfilesNbr = 50
+	}
+
+	variables := map[string]interface{}{
+		"count": maxProfilesNbr,
+	}
+	if cursor != "" {
+		variables["cursor"] = cursor
+	}
+
+	jsn, err := s.getCommunityTimeline(ctx, "KDAssJ5lafCy-asH4wm1dw/membersSliceTimeline_Query", communityID, variables)
+	if err != nil {
+		return nil, "", err
+	}
+
+	slice := jsn.Data.CommunityResults.Result.MembersSlice
+	var profiles []*Profile
+	for _, item := range slice.ItemsResults {
+		if item.Result.RestId == "" {
+			continue
+		}
+		profile := parseProfile(item.Result)
+		profiles = append(profiles, &profile)
+	}
+	return profiles, slice.SliceInfo.NextCursor, nil
+}
+
+// getCommunityTimeline gets GraphQL community operation for a given community
+func (s *Scraper) getCommunityTimeline(ctx context.Context, operation string, communityID string, variables map[string]interface{}) (*communityTimeline, error) {
+	if variables == nil {
+		variables = map[string]interface{}{}
+	}
+	variables["communityId"] = communityID
+
+	req, err := s.newGraphQLRequest(operation, variables)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	var jsn communityTimeline
+	err = s.RequestAPI(req, &jsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(jsn.Errors) > 0 && jsn.Data.CommunityResults.Result.TypeName == "" {
+		return nil, jsn.Errors[0]
+	}
+	return &jsn, nil
+}
+
+func parseCommunity(community communityResult) Community {
+	c := Community{
+		ID:             community.IDStr,
+		Name:           community.Name,
+		Description:    community.Description,
+		Question:       community.Question,
+		Banner:         community.CustomBannerMedia.MediaInfo.OriginalImgURL,
+		IsNSFW:         community.IsNSFW,
+		JoinPolicy:     community.JoinPolicy,
+		MemberCount:    community.MemberCount,
+		ModeratorCount: community.ModeratorCount,
+		URL:            "https://twitter.com/i/communities/" + community.IDStr,
+	}
+
+	if c.Banner == "" {
+		c.Banner = community.DefaultBannerMedia.MediaInfo.OriginalImgURL
+	}
+
+	if community.CreatedAt > 0 {
+		tm := time.Unix(0, community.CreatedAt*int64(time.Millisecond)).UTC()
+		c.Created = &tm
+	}
+
+	for _, rule := range community.Rules {
+		c.Rules = append(c.Rules, CommunityRule{
+			ID:          rule.RestId,
+			Name:        rule.Name,
+			Description: rule.Description,
+		})
+	}
+
+	if community.AdminResults.Result.RestId != "" {
+		c.Admin = parseProfile(community.AdminResults.Result)
+	}
+	if community.CreatorResults.Result.RestId != "" {
+		c.Creator = parseProfile(community.CreatorResults.Result)
+	}
+
+	return c
+}
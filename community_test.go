@@ -0,0 +1,105 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+
+	twitterscraper "github.com/n0madic/twitter-scraper"
+)
+
+func TestGetCommunityFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	community, err := scraper.GetCommunity(context.Background(), "1712900000000000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if community.ID != "1712900000000000001" || community.Name != "Go Developers" {
+		t.Errorf("Unexpected community %s %s", community.ID, community.Name)
+	}
+	if community.MemberCount != 52000 || community.ModeratorCount != 7 {
+		t.Errorf("Unexpected community counts %d/%d", community.MemberCount, community.ModeratorCount)
+	}
+	if community.JoinPolicy != "Open" {
+		t.Errorf("Expected JoinPolicy Open, got %s", community.JoinPolicy)
+	}
+	if len(community.Rules) != 2 || community.Rules[0].Name != "Be kind" || community.Rules[0].Description != "No harassment" {
+		t.Errorf("Unexpected community rules %v", community.Rules)
+	}
+	if community.Admin.Username != "TwitterDev" || community.Creator.Username != "TwitterDev" {
+		t.Errorf("Unexpected community admin %s and creator %s", community.Admin.Username, community.Creator.Username)
+	}
+	if community.Created == nil || community.Created.Unix() != 1690000000 {
+		t.Errorf("Unexpected community Created %v", community.Created)
+	}
+}
+
+func TestGetCommunityTweetsFixture(t *testing.T) {
+	for sort, rankingMode := range map[twitterscraper.CommunitySort]string{
+		twitterscraper.CommunityTop:    "Relevance",
+		twitterscraper.CommunityLatest: "Recency",
+	} {
+		scraper, transport := twitterscraper.NewFixtureScraper()
+		count := 0
+		for tweet := range scraper.GetCommunityTweets(context.Background(), "1712900000000000001", sort, 10) {
+			if tweet.Error != nil {
+				t.Fatal(tweet.Error)
+			}
+			if tweet.CommunityID != "1712900000000000001" {
+				t.Errorf("Expected tweet CommunityID 1712900000000000001, got %s", tweet.CommunityID)
+			}
+			count++
+		}
+		if count != 2 {
+			t.Errorf("Expected 2 community tweets, got %d", count)
+		}
+		variables := twitterscraper.FixtureVariables(transport.Requests()[0])
+		if variables["rankingMode"] != rankingMode {
+			t.Errorf("Expected rankingMode %s, got %v", rankingMode, variables["rankingMode"])
+		}
+		if variables["communityId"] != "1712900000000000001" {
+			t.Errorf("Expected communityId 1712900000000000001, got %v", variables["communityId"])
+		}
+	}
+}
+
+func TestGetCommunityMembersFixture(t *testing.T) {
+	scraper, transport := twitterscraper.NewFixtureScraper()
+	var members []string
+	for profile := range scraper.GetCommunityMembers(context.Background(), "1712900000000000001", 10) {
+		if profile.Error != nil {
+			t.Fatal(profile.Error)
+		}
+		members = append(members, profile.Username)
+	}
+	if len(members) != 3 || members[2] != "Support" {
+		t.Errorf("Unexpected community members %v", members)
+	}
+	requests := transport.Requests()
+	if len(requests) == 0 {
+		t.Fatal("Expected community members request")
+	}
+	if count := twitterscraper.FixtureVariables(requests[0])["count"]; count != float64(10) {
+		t.Errorf("Expected count 10, got %v", count)
+	}
+}
+
+type communityContextKey struct{}
+
+func TestCommunityContextFixture(t *testing.T) {
+	scraper, transport := twitterscraper.NewFixtureScraper()
+	ctx := context.WithValue(context.Background(), communityContextKey{}, "community")
+	for range scraper.GetCommunityTweets(ctx, "1712900000000000001", twitterscraper.CommunityTop, 10) {
+	}
+	for range scraper.GetCommunityMembers(ctx, "1712900000000000001", 10) {
+	}
+
+	requests := transport.Requests()
+	if len(requests) == 0 {
+		t.Fatal("Expected community requests")
+	}
+	for _, req := range requests {
+		if req.Context().Value(communityContextKey{}) != "community" {
+			t.Errorf("Expected request %s with the context of the caller", req.URL.Path)
+		}
+	}
+}
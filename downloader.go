@@ -0,0 +1,430 @@
+package twitterscraper
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ManifestFile is the name of the manifest written by MediaDownloader into the directory.
+const ManifestFile = "manifest.json"
+
+// MediaDownloader downloads original photos and videos of tweets via the HTTP client
+// and proxy of the Scraper. Files are named by SHA-256 of the content, so the same
+// media shared by several tweets is stored once. Interrupted downloads are resumed.
+type MediaDownloader struct {
+	scraper     *Scraper
+	dir         string
+	concurrency int
+	mu          sync.Mutex
+}
+
+// DownloadedMedia is a file of the tweet media.
+// MediaID is SHA-256 of the media URL for media without ID.
+type DownloadedMedia struct {
+	TweetID string `json:"tweet_id"`
+	MediaID string `json:"media_id"`
+	Type    string `json:"type"`
+	URL     string `json:"url"`
+	File    string `json:"file"`
+	SHA256  string `json:"sha256"`
+	Size    int64  `json:"size"`
+}
+
+// MediaManifest maps tweet and media IDs to downloaded files.
+type MediaManifest struct {
+	Files []DownloadedMedia `json:"files"`
+}
+
+type downloadJob struct {
+	tweetID string
+	mediaID string
+	media   Media
+}
+
+func newDownloadJob(tweetID string, media Media) downloadJob {
+	mediaID := media.IDStr
+	if mediaID == "" {
+		sum := sha256.Sum256([]byte(media.MediaURLHttps))
+		mediaID = hex.EncodeToString(sum[:])
+	}
+	return downloadJob{tweetID: tweetID, mediaID: mediaID, media: media}
+}
+
+// NewMediaDownloader creates a MediaDownloader which saves files into the directory.
+func (s *Scraper) NewMediaDownloader(dir string) *MediaDownloader {
+	return &MediaDownloader{
+		scraper:     s,
+		dir:         dir,
+		concurrency: 4,
+	}
+}
+
+// WithConcurrency sets number of parallel downloads, 4 by default.
+func (d *MediaDownloader) WithConcurrency(n int) *MediaDownloader {
+	if n > 0 {
+		d.concurrency = n
+	}
+	return d
+}
+
+// Download downloads all media of the tweets and updates the manifest.
+func (d *MediaDownloader) Download(ctx context.Context, tweets ...*Tweet) ([]DownloadedMedia, error) {
+	var jobs []downloadJob
+	for _, tweet := range tweets {
+		for _, media := range tweet.Media {
+			jobs = append(jobs, newDownloadJob(tweet.ID, media))
+		}
+	}
+	return d.download(ctx, jobs)
+}
+
+// DownloadMedia downloads media items of the tweet and updates the manifest.
+func (d *MediaDownloader) DownloadMedia(ctx context.Context, tweetID string, media ...Media) ([]DownloadedMedia, error) {
+	var jobs []downloadJob
+	for _, m := range media {
+		jobs = append(jobs, newDownloadJob(tweetID, m))
+	}
+	return d.download(ctx, jobs)
+}
+
+// Manifest returns the manifest of the directory.
+func (d *MediaDownloader) Manifest() (*MediaManifest, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.readManifest()
+}
+
+func (d *MediaDownloader) download(ctx context.Context, jobs []downloadJob) ([]DownloadedMedia, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	err := os.MkdirAll(d.dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := d.readManifest()
+	if err != nil {
+		return nil, err
+	}
+	downloaded := make(map[string]DownloadedMedia)
+	for _, file := range manifest.Files {
+		if _, err := os.Stat(filepath.Join(d.dir, file.File)); err == nil {
+			downloaded[file.MediaID] = file
+		}
+	}
+
+	// downloads can take longer than the API requests, they are limited by context only
+	client := *d.scraper.client
+	client.Timeout = 0
+
+	// the same media can be attached to several tweets, it is downloaded once
+	var mu sync.Mutex
+	fetched := make(map[string]DownloadedMedia)
+	errs := make(map[string]error)
+	started := make(map[string]bool)
+	sem := make(chan struct{}, d.concurrency)
+	var wg sync.WaitGroup
+	for _, job := range jobs {
+		if _, ok := downloaded[job.mediaID]; ok || started[job.mediaID] {
+			continue
+		}
+		started[job.mediaID] = true
+
+		wg.Add(1)
+		go func(job downloadJob) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				mu.Lock()
+				errs[job.mediaID] = ctx.Err()
+				mu.Unlock()
+				return
+			}
+			file, err := d.downloadFile(ctx, &client, job)
+			mu.Lock()
+			if err != nil {
+				errs[job.mediaID] = err
+			} else {
+				fetched[job.mediaID] = file
+			}
+			mu.Unlock()
+		}(job)
+	}
+	wg.Wait()
+
+	for id, file := range fetched {
+		downloaded[id] = file
+	}
+
+	index := make(map[[2]string]int)
+	for i, file := range manifest.Files {
+		index[[2]string{file.TweetID, file.MediaID}] = i
+	}
+
+	var results []DownloadedMedia
+	var firstErr error
+	failed := 0
+	for _, job := range jobs {
+		if err := errs[job.mediaID]; err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			failed++
+			continue
+		}
+		file := downloaded[job.mediaID]
+		file.TweetID = job.tweetID
+		results = append(results, file)
+
+		key := [2]string{file.TweetID, file.MediaID}
+		if i, ok := index[key]; ok {
+			manifest.Files[i] = file
+		} else {
+			index[key] = len(manifest.Files)
+			manifest.Files = append(manifest.Files, file)
+		}
+	}
+
+	err = d.writeManifest(manifest)
+	if err != nil {
+		return results, err
+	}
+
+	if firstErr != nil {
+		return results, fmt.Errorf("%d of %d media failed: %w", failed, len(jobs), firstErr)
+	}
+	return results, nil
+}
+
+// downloadFile downloads media into partial file, resuming it if one exists,
+// and renames it by SHA-256 of the content when complete. The resumed request is
+// conditional on the validator of the first response, so changed media is started over.
+func (d *MediaDownloader) downloadFile(ctx context.Context, client *http.Client, job downloadJob) (DownloadedMedia, error) {
+	mediaURL, err := originalMediaURL(job.media)
+	if err != nil {
+		return DownloadedMedia{}, err
+	}
+	u, err := url.Parse(mediaURL)
+	if err != nil {
+		return DownloadedMedia{}, err
+	}
+	ext := path.Ext(u.Path)
+	if ext == ".m3u8" {
+		return d.downloadHLSFile(ctx, job, mediaURL)
+	}
+
+	partial := filepath.Join(d.dir, job.mediaID+ext+".part")
+	f, err := os.OpenFile(partial, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return DownloadedMedia{}, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return DownloadedMedia{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", mediaURL, nil)
+	if err != nil {
+		return DownloadedMedia{}, err
+	}
+	validatorFile := partial + ".validator"
+	if info.Size() > 0 {
+		validator, err := ioutil.ReadFile(validatorFile)
+		if err == nil && len(validator) > 0 {
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-", info.Size()))
+			req.Header.Set("If-Range", string(validator))
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return DownloadedMedia{}, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		err = f.Truncate(0)
+		if err == nil {
+			_, err = f.Seek(0, io.SeekStart)
+		}
+		if err == nil {
+			err = ioutil.WriteFile(validatorFile, []byte(responseValidator(resp)), 0644)
+		}
+		if err == nil {
+			_, err = io.Copy(f, resp.Body)
+		}
+	case http.StatusPartialContent:
+		_, err = f.Seek(0, io.SeekEnd)
+		if err == nil {
+			_, err = io.Copy(f, resp.Body)
+		}
+	case http.StatusRequestedRangeNotSatisfiable:
+		// partial file is already complete
+	default:
+		return DownloadedMedia{}, fmt.Errorf("download %s: response status %s", mediaURL, resp.Status)
+	}
+	if err != nil {
+		return DownloadedMedia{}, err
+	}
+	err = f.Close()
+	if err != nil {
+		return DownloadedMedia{}, err
+	}
+
+	file, err := d.completeFile(job, mediaURL, partial, ext)
+	if err != nil {
+		return file, err
+	}
+	os.Remove(validatorFile)
+	return file, nil
+}
+
+// responseValidator returns strong ETag or Last-Modified of the response for If-Range header
+func responseValidator(resp *http.Response) string {
+	if etag := resp.Header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return resp.Header.Get("Last-Modified")
+}
+
+// downloadHLSFile downloads segments of the HLS-only video into single file,
+// MPEG-TS segments can't be resumed and partial file is started over
+func (d *MediaDownloader) downloadHLSFile(ctx context.Context, job downloadJob, playlistURL string) (DownloadedMedia, error) {
+	playlist, err := d.scraper.GetHLSPlaylist(ctx, playlistURL)
+	if err != nil {
+		return DownloadedMedia{}, err
+	}
+	if playlist.IsMaster() {
+		variant, _ := playlist.BestVariant()
+		playlist, err = d.scraper.GetHLSPlaylist(ctx, variant.URL)
+		if err != nil {
+			return DownloadedMedia{}, err
+		}
+	}
+	// segments with initialization section are fragmented MP4
+	ext := ".ts"
+	if playlist.InitSegment != "" {
+		ext = ".mp4"
+	}
+
+	partial := filepath.Join(d.dir, job.mediaID+ext+".part")
+	f, err := os.Create(partial)
+	if err != nil {
+		return DownloadedMedia{}, err
+	}
+	defer f.Close()
+	err = d.scraper.DownloadHLS(ctx, playlist, f, d.concurrency)
+	if err != nil {
+		return DownloadedMedia{}, err
+	}
+	err = f.Close()
+	if err != nil {
+		return DownloadedMedia{}, err
+	}
+
+	return d.completeFile(job, playlistURL, partial, ext)
+}
+
+// completeFile renames downloaded partial file by SHA-256 of the content
+func (d *MediaDownloader) completeFile(job downloadJob, mediaURL, partial, ext string) (DownloadedMedia, error) {
+	sum, size, err := fileSHA256(partial)
+	if err != nil {
+		return DownloadedMedia{}, err
+	}
+	file := sum + ext
+	err = os.Rename(partial, filepath.Join(d.dir, file))
+	if err != nil {
+		return DownloadedMedia{}, err
+	}
+
+	return DownloadedMedia{
+		TweetID: job.tweetID,
+		MediaID: job.mediaID,
+		Type:    job.media.Type,
+		URL:     mediaURL,
+		File:    file,
+		SHA256:  sum,
+		Size:    size,
+	}, nil
+}
+
+func (d *MediaDownloader) readManifest() (*MediaManifest, error) {
+	var manifest MediaManifest
+	b, err := ioutil.ReadFile(filepath.Join(d.dir, ManifestFile))
+	if os.IsNotExist(err) {
+		return &manifest, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(b, &manifest)
+	if err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+	return &manifest, nil
+}
+
+// writeManifest replaces the manifest atomically
+func (d *MediaDownloader) writeManifest(manifest *MediaManifest) error {
+	b, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := filepath.Join(d.dir, ManifestFile+".tmp")
+	err = ioutil.WriteFile(tmp, b, 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(d.dir, ManifestFile))
+}
+
+// originalMediaURL returns URL of the photo in original size or of the MP4 video with the highest bitrate,
+// HLS playlist for videos without MP4 variants
+func originalMediaURL(m Media) (string, error) {
+	switch m.Type {
+	case "photo":
+		return m.MediaURLHttps + "?name=orig", nil
+	case "video", "animated_gif":
+		video := parseVideo(m)
+		best := video.BestVariant()
+		if best.ContentType == "video/mp4" {
+			return best.URL, nil
+		}
+		if video.HLSURL != "" {
+			return video.HLSURL, nil
+		}
+		return "", fmt.Errorf("media %s has no MP4 variant", m.IDStr)
+	}
+	return "", fmt.Errorf("media %s has unsupported type %s", m.IDStr, m.Type)
+}
+
+func fileSHA256(name string) (string, int64, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
@@ -0,0 +1,239 @@
+package twitterscraper_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/n0madic/twitter-scraper"
+)
+
+type mediaServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []*http.Request
+}
+
+func newMediaServer(photo, video []byte) *mediaServer {
+	srv := &mediaServer{}
+	srv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv.mu.Lock()
+		srv.requests = append(srv.requests, r)
+		srv.mu.Unlock()
+		switch {
+		case r.URL.Path == "/media/photo.jpg" && r.URL.Query().Get("name") == "orig":
+			http.ServeContent(w, r, "photo.jpg", time.Time{}, bytes.NewReader(photo))
+		case r.URL.Path == "/vid/1280x720/high.mp4":
+			w.Header().Set("ETag", `"video-v1"`)
+			http.ServeContent(w, r, "high.mp4", time.Time{}, bytes.NewReader(video))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	return srv
+}
+
+func (srv *mediaServer) Requests() []*http.Request {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	return append([]*http.Request(nil), srv.requests...)
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func testMediaTweets(srvURL string) []*twitterscraper.Tweet {
+	photo := twitterscraper.Media{IDStr: "1001", Type: "photo", MediaURLHttps: srvURL + "/media/photo.jpg"}
+	video := twitterscraper.Media{IDStr: "1002", Type: "video", MediaURLHttps: srvURL + "/media/thumb.jpg"}
+	video.VideoInfo.Variants = []struct {
+		Bitrate     int    `json:"bitrate,omitempty"`
+		ContentType string `json:"content_type"`
+		URL         string `json:"url"`
+	}{
+		{ContentType: "application/x-mpegURL", URL: srvURL + "/vid/playlist.m3u8"},
+		{Bitrate: 256000, ContentType: "video/mp4", URL: srvURL + "/vid/480x270/low.mp4"},
+		{Bitrate: 2176000, ContentType: "video/mp4", URL: srvURL + "/vid/1280x720/high.mp4?tag=12"},
+	}
+	return []*twitterscraper.Tweet{
+		{ID: "1", Media: []twitterscraper.Media{photo, video}},
+		{ID: "2", Media: []twitterscraper.Media{photo}},
+	}
+}
+
+func TestMediaDownloader(t *testing.T) {
+	photo := []byte("original photo")
+	video := bytes.Repeat([]byte("0123456789"), 100)
+	srv := newMediaServer(photo, video)
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "media")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// interrupted download of the video
+	err = ioutil.WriteFile(filepath.Join(dir, "1002.mp4.part"), video[:400], 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "1002.mp4.part.validator"), []byte(`"video-v1"`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	downloader := twitterscraper.New().NewMediaDownloader(dir).WithConcurrency(2)
+	files, err := downloader.Download(context.Background(), testMediaTweets(srv.URL)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("Expected 3 files, got %d", len(files))
+	}
+	if files[0].File != sha256Hex(photo)+".jpg" || files[1].File != sha256Hex(video)+".mp4" || files[2].File != files[0].File {
+		t.Errorf("Unexpected files %v", files)
+	}
+	if files[2].TweetID != "2" || files[2].MediaID != "1001" {
+		t.Errorf("Unexpected file of the second tweet %v", files[2])
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, files[1].File))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, video) {
+		t.Errorf("Unexpected content of the resumed video, %d bytes", len(b))
+	}
+
+	requests := srv.Requests()
+	if len(requests) != 2 {
+		t.Errorf("Expected 2 requests, got %d", len(requests))
+	}
+	for _, req := range requests {
+		if req.URL.Path == "/vid/1280x720/high.mp4" && (req.Header.Get("Range") != "bytes=400-" || req.Header.Get("If-Range") != `"video-v1"`) {
+			t.Errorf("Expected video download is resumed, got Range %q If-Range %q", req.Header.Get("Range"), req.Header.Get("If-Range"))
+		}
+	}
+
+	var manifest twitterscraper.MediaManifest
+	b, err = ioutil.ReadFile(filepath.Join(dir, twitterscraper.ManifestFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = json.Unmarshal(b, &manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest.Files) != 3 || manifest.Files[1].SHA256 != sha256Hex(video) || manifest.Files[1].Size != int64(len(video)) {
+		t.Errorf("Unexpected manifest %v", manifest.Files)
+	}
+
+	// downloaded files are skipped
+	_, err = downloader.Download(context.Background(), testMediaTweets(srv.URL)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(srv.Requests()) != 2 {
+		t.Errorf("Expected no new requests, got %d", len(srv.Requests())-2)
+	}
+}
+
+func TestMediaDownloaderChangedMedia(t *testing.T) {
+	video := bytes.Repeat([]byte("0123456789"), 100)
+	srv := newMediaServer(nil, video)
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "media")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// partial file of the previous version of the video
+	err = ioutil.WriteFile(filepath.Join(dir, "1002.mp4.part"), []byte("stale content"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "1002.mp4.part.validator"), []byte(`"video-v0"`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	media := testMediaTweets(srv.URL)[0].Media[1]
+	files, err := twitterscraper.New().NewMediaDownloader(dir).DownloadMedia(context.Background(), "1", media)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].SHA256 != sha256Hex(video) || files[0].Size != int64(len(video)) {
+		t.Errorf("Expected video is downloaded again, got %v", files)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "1002.mp4.part.validator")); !os.IsNotExist(err) {
+		t.Errorf("Expected validator is removed, got %v", err)
+	}
+}
+
+func TestMediaDownloaderWithoutID(t *testing.T) {
+	photo := []byte("original photo")
+	srv := newMediaServer(photo, nil)
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "media")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	media := twitterscraper.Media{Type: "photo", MediaURLHttps: srv.URL + "/media/photo.jpg"}
+	downloader := twitterscraper.New().NewMediaDownloader(dir)
+	files, err := downloader.DownloadMedia(context.Background(), "4", media, media)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 || files[0].MediaID != sha256Hex([]byte(media.MediaURLHttps)) || files[0].File != sha256Hex(photo)+".jpg" {
+		t.Errorf("Unexpected files %v", files)
+	}
+	if len(srv.Requests()) != 1 {
+		t.Errorf("Expected 1 request, got %d", len(srv.Requests()))
+	}
+
+	// downloaded files are skipped
+	_, err = downloader.DownloadMedia(context.Background(), "4", media)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(srv.Requests()) != 1 {
+		t.Errorf("Expected no new requests, got %d", len(srv.Requests())-1)
+	}
+}
+
+func TestMediaDownloaderError(t *testing.T) {
+	srv := newMediaServer(nil, nil)
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "media")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	missing := twitterscraper.Media{IDStr: "1003", Type: "photo", MediaURLHttps: srv.URL + "/media/missing.jpg"}
+	files, err := twitterscraper.New().NewMediaDownloader(dir).DownloadMedia(context.Background(), "3", missing)
+	if err == nil {
+		t.Error("Expected error for missing media")
+	}
+	if len(files) != 0 {
+		t.Errorf("Expected no files, got %v", files)
+	}
+}
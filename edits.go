@@ -0,0 +1,203 @@
+package twitterscraper
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"unicode"
+)
+
+// TweetVersion is a version of the edited tweet. Tweet.ID is the initial tweet for all versions,
+// VersionID is the ID of this version. Diff is the word-level change of the text from the
+// previous version, nil for the initial one.
+type TweetVersion struct {
+	Tweet
+	VersionID string
+	Diff      []TextDiff
+}
+
+// DiffType type
+type DiffType int
+
+const (
+	// DiffEqual - text of both versions
+	DiffEqual DiffType = iota
+	// DiffInsert - text added in the newer version
+	DiffInsert
+	// DiffDelete - text removed from the older version
+	DiffDelete
+)
+
+// TextDiff is a part of the difference between two texts.
+type TextDiff struct {
+	Type DiffType
+	Text string
+}
+
+// editControl JSON object of the tweet, versions after the initial one keep it in edit_control_initial
+type editControl struct {
+	InitialTweetId     string       `json:"initial_tweet_id"`
+	EditIds            []string     `json:"edit_tweet_ids"`
+	EditableUntil      json.Number  `json:"editable_until_msecs"`
+	IsEditable         bool         `json:"is_edit_eligible"`
+	EditsRemaining     json.Number  `json:"edits_remaining"`
+	EditControlInitial *editControl `json:"edit_control_initial"`
+}
+
+// tweetEditHistory JSON object
+type tweetEditHistory struct {
+	Errors []Err `json:"errors"`
+	Data   struct {
+		TweetEditHistory struct {
+			Timeline timelineV2 `json:"timeline"`
+		} `json:"tweet_edit_history"`
+	} `json:"data"`
+}
+
+// GetTweetEditHistory returns all versions of the edited tweet from the initial to the latest,
+// with word-level diff of the text between consecutive versions.
+func (s *Scraper) GetTweetEditHistory(ctx context.Context, tweetID string) ([]TweetVersion, error) {
+	variables := map[string]interface{}{
+		"tweetId":                                tweetID,
+		"withQuickPromoteEligibilityTweetFields": false,
+	}
+	req, err := s.newGraphQLRequest("8eaWKjHszkS-G_hprUd9AA/TweetEditHistory", variables)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	var jsn tweetEditHistory
+	err = s.RequestAPI(req, &jsn)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []TweetVersion
+	seen := make(map[string]bool)
+	for _, instruction := range jsn.Data.TweetEditHistory.Timeline.Instructions {
+		for _, entry := range instruction.Entries {
+			for _, content := range entry.itemContents() {
+				result := content.TweetResults.Result
+				if result.TypeName == "TweetWithVisibilityResults" && result.Tweet != nil {
+					result = *result.Tweet
+				}
+				tweet, err := parseTweetResult(result)
+				if err != nil || seen[result.RestId] {
+					continue
+				}
+				seen[result.RestId] = true
+				if s.quoteDepth > 0 {
+					limitQuoteDepth(&tweet, s.quoteDepth)
+				}
+				versions = append(versions, TweetVersion{Tweet: tweet, VersionID: result.RestId})
+			}
+		}
+	}
+
+	if len(versions) == 0 {
+		if len(jsn.Errors) > 0 {
+			return nil, jsn.Errors[0]
+		}
+		return nil, ErrTweetNotFound
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		if !versions[i].TimeParsed.Equal(versions[j].TimeParsed) {
+			return versions[i].TimeParsed.Before(versions[j].TimeParsed)
+		}
+		return len(versions[i].VersionID) < len(versions[j].VersionID) ||
+			len(versions[i].VersionID) == len(versions[j].VersionID) && versions[i].VersionID < versions[j].VersionID
+	})
+	for i := 1; i < len(versions); i++ {
+		versions[i].Diff = DiffWords(versions[i-1].ExpandedText, versions[i].ExpandedText)
+	}
+	return versions, nil
+}
+
+// parseEditControl sets edit history and editable window of the tweet
+func parseEditControl(tweet *Tweet, ctl editControl) {
+	if ctl.EditControlInitial != nil {
+		ctl = *ctl.EditControlInitial
+	}
+	tweet.EditIds = ctl.EditIds
+	tweet.IsEditable = ctl.IsEditable
+	if until := msecToTime(ctl.EditableUntil); until != nil {
+		tweet.EditableUntil = *until
+	}
+	tweet.EditsRemaining, _ = strconv.Atoi(ctl.EditsRemaining.String())
+}
+
+// DiffWords returns word-level difference between oldText and newText,
+// words and whitespace between them are compared as separate tokens.
+func DiffWords(oldText, newText string) []TextDiff {
+	a, b := splitWords(oldText), splitWords(newText)
+
+	// lcs[i][j] is length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var diff []TextDiff
+	add := func(t DiffType, text string) {
+		if n := len(diff); n > 0 && diff[n-1].Type == t {
+			diff[n-1].Text += text
+			return
+		}
+		diff = append(diff, TextDiff{Type: t, Text: text})
+	}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			add(DiffEqual, a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			add(DiffDelete, a[i])
+			i++
+		default:
+			add(DiffInsert, b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		add(DiffDelete, a[i])
+	}
+	for ; j < len(b); j++ {
+		add(DiffInsert, b[j])
+	}
+	return diff
+}
+
+// splitWords splits text into words and runs of whitespace
+func splitWords(text string) []string {
+	var tokens []string
+	start := 0
+	prevSpace := false
+	for i, r := range text {
+		space := unicode.IsSpace(r)
+		if i > 0 && space != prevSpace {
+			tokens = append(tokens, text[start:i])
+			start = i
+		}
+		prevSpace = space
+	}
+	if start < len(text) {
+		tokens = append(tokens, text[start:])
+	}
+	return tokens
+}
@@ -0,0 +1,85 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	twitterscraper "github.com/n0madic/twitter-scraper"
+)
+
+func TestGetTweetEditHistoryFixture(t *testing.T) {
+	scraper, transport := twitterscraper.NewFixtureScraper()
+	versions, err := scraper.GetTweetEditHistory(context.Background(), "1713200000000000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tweetID := twitterscraper.FixtureVariables(transport.Requests()[0])["tweetId"]; tweetID != "1713200000000000001" {
+		t.Errorf("Expected tweetId 1713200000000000001, got %v", tweetID)
+	}
+
+	expectedIDs := []string{"1713200000000000001", "1713200000000000002", "1713200000000000003"}
+	if len(versions) != len(expectedIDs) {
+		t.Fatalf("Expected %d versions, got %d", len(expectedIDs), len(versions))
+	}
+	for i, id := range expectedIDs {
+		if versions[i].VersionID != id || versions[i].ID != expectedIDs[0] {
+			t.Errorf("Unexpected version #%d ID %s of tweet %s", i, versions[i].VersionID, versions[i].ID)
+		}
+		if diff := cmp.Diff(expectedIDs, versions[i].EditIds); diff != "" {
+			t.Errorf("Unexpected EditIds of version #%d %s", i, diff)
+		}
+		if !versions[i].IsEditable || versions[i].EditsRemaining != 3 ||
+			!versions[i].EditableUntil.Equal(time.Date(2023, 10, 11, 15, 0, 0, 0, time.UTC)) {
+			t.Errorf("Unexpected edit window of version #%d %v %d %s",
+				i, versions[i].IsEditable, versions[i].EditsRemaining, versions[i].EditableUntil)
+		}
+	}
+
+	if versions[0].Diff != nil {
+		t.Errorf("Expected no diff of the initial version, got %v", versions[0].Diff)
+	}
+	expected := []twitterscraper.TextDiff{
+		{Type: twitterscraper.DiffEqual, Text: "Hello "},
+		{Type: twitterscraper.DiffDelete, Text: "wrold"},
+		{Type: twitterscraper.DiffInsert, Text: "world"},
+		{Type: twitterscraper.DiffEqual, Text: " from the team"},
+	}
+	if diff := cmp.Diff(expected, versions[1].Diff); diff != "" {
+		t.Error("Resulting diff does not match the sample", diff)
+	}
+	expected = []twitterscraper.TextDiff{
+		{Type: twitterscraper.DiffEqual, Text: "Hello world from the "},
+		{Type: twitterscraper.DiffInsert, Text: "whole "},
+		{Type: twitterscraper.DiffEqual, Text: "team"},
+		{Type: twitterscraper.DiffInsert, Text: " & friends"},
+	}
+	if diff := cmp.Diff(expected, versions[2].Diff); diff != "" {
+		t.Error("Resulting diff does not match the sample", diff)
+	}
+}
+
+func TestDiffWords(t *testing.T) {
+	expected := []twitterscraper.TextDiff{
+		{Type: twitterscraper.DiffDelete, Text: "old text"},
+	}
+	if diff := cmp.Diff(expected, twitterscraper.DiffWords("old text", "")); diff != "" {
+		t.Error("Resulting diff does not match the sample", diff)
+	}
+
+	expected = []twitterscraper.TextDiff{
+		{Type: twitterscraper.DiffEqual, Text: "one "},
+		{Type: twitterscraper.DiffDelete, Text: "two"},
+		{Type: twitterscraper.DiffInsert, Text: "2"},
+		{Type: twitterscraper.DiffEqual, Text: "  three\n"},
+		{Type: twitterscraper.DiffInsert, Text: "four"},
+	}
+	if diff := cmp.Diff(expected, twitterscraper.DiffWords("one two  three\n", "one 2  three\nfour")); diff != "" {
+		t.Error("Resulting diff does not match the sample", diff)
+	}
+
+	if diff := twitterscraper.DiffWords("", ""); diff != nil {
+		t.Errorf("Expected no diff of empty texts, got %v", diff)
+	}
+}
@@ -0,0 +1,116 @@
+package twitterscraper
+
+import "context"
+
+// engagementTimeline JSON object returned by Retweeters and Favoriters
+type engagementTimeline struct {
+	Errors []Err `json:"errors"`
+	Data   struct {
+		RetweetersTimeline struct {
+			Timeline timelineV2 `json:"timeline"`
+		} `json:"retweeters_timeline"`
+		FavoritersTimeline struct {
+			Timeline timelineV2 `json:"timeline"`
+		} `json:"favoriters_timeline"`
+	} `json:"data"`
+}
+
+// GetRetweeters returns channel with users who retweeted a given tweet.
+func (s *Scraper) GetRetweeters(ctx context.Context, tweetID string, maxProfilesNbr int) <-chan *ProfileResult {
+	return getUserTimeline(ctx, tweetID, maxProfilesNbr, func(tweetID string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+		return s.fetchRetweeters(ctx, tweetID, maxProfilesNbr, cursor)
+	})
+}
+
+// FetchRetweeters gets users who retweeted a given tweet, via the Twitter frontend API.
+func (s *Scraper) FetchRetweeters(tweetID string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+	return s.fetchRetweeters(context.Background(), tweetID, maxProfilesNbr, cursor)
+}
+
+// fetchRetweeters gets users who retweeted a given tweet with the context
+func (s *Scraper) fetchRetweeters(ctx context.Context, tweetID string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+	jsn, err := s.getEngagementTimeline(ctx, "0BoJlKAxoNPQUHRftlwZ2w/Retweeters", tweetID, maxProfilesNbr, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	profiles, nextCursor := jsn.Data.RetweetersTimeline.Timeline.parseUsers()
+	return profiles, nextCursor, nil
+}
+
+// GetFavoriters returns channel with users who liked a given tweet.
+func (s *Scraper) GetFavoriters(ctx context.Context, tweetID string, maxProfilesNbr int) <-chan *ProfileResult {
+	return getUserTimeline(ctx, tweetID, maxProfilesNbr, func(tweetID string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+		return s.fetchFavoriters(ctx, tweetID, maxProfilesNbr, cursor)
+	})
+}
+
+// FetchFavoriters gets users who liked a given tweet, via the Twitter frontend API.
+func (s *Scraper) FetchFavoriters(tweetID string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+	return s.fetchFavoriters(context.Background(), tweetID, maxProfilesNbr, cursor)
+}
+
+// fetchFavoriters gets users who liked a given tweet with the context
+func (s *Scraper) fetchFavoriters(ctx context.Context, tweetID string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+	jsn, err := s.getEngagementTimeline(ctx, "XRRjv1-uj1HZn3o324etOQ/Favoriters", tweetID, maxProfilesNbr, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	profiles, nextCursor := jsn.Data.FavoritersTimeline.Timeline.parseUsers()
+	return profiles, nextCursor, nil
+}
+
+// GetQuoteTweets returns channel with tweets quoting a given tweet.
+func (s *Scraper) GetQuoteTweets(ctx context.Context, tweetID string, maxTweetsNbr int) <-chan *TweetResult {
+	return getTweetTimeline(ctx, tweetID, maxTweetsNbr, func(tweetID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+		return s.fetchQuoteTweets(ctx, tweetID, maxTweetsNbr, cursor)
+	})
+}
+
+// FetchQuoteTweets gets tweets quoting a given tweet, via the Twitter frontend API.
+func (s *Scraper) FetchQuoteTweets(tweetID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	return s.fetchQuoteTweets(context.Background(), tweetID, maxTweetsNbr, cursor)
+}
+
+// fetchQuoteTweets gets tweets quoting a given tweet with the context
+func (s *Scraper) fetchQuoteTweets(ctx context.Context, tweetID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	timeline, err := s.getSearchTimeline(ctx, "quoted_tweet_id:"+tweetID, "Latest", maxTweetsNbr, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	tweets, nextCursor := timeline.parseTweets()
+	return s.prepareTweets(tweets), nextCursor, nil
+}
+
+// getEngagementTimeline gets users engaged with a given tweet, via the Twitter frontend GraphQL API
+func (s *Scraper) getEngagementTimeline(ctx context.Context, operation string, tweetID string, maxNbr int, cursor string) (*engagementTimeline, error) {
+	if maxNbr > 50 {
+		maxNbr = 50
+	}
+
+	variables := map[string]interface{}{
+		"tweetId":                tweetID,
+		"count":                  maxNbr,
+		"includePromotedContent": false,
+	}
+	if cursor != "" {
+		variables["cursor"] = cursor
+	}
+
+	req, err := s.newGraphQLRequest(operation, variables)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	var jsn engagementTimeline
+	err = s.RequestAPI(req, &jsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(jsn.Errors) > 0 && len(jsn.Data.RetweetersTimeline.Timeline.Instructions) == 0 &&
+		len(jsn.Data.FavoritersTimeline.Timeline.Instructions) == 0 {
+		return nil, jsn.Errors[0]
+	}
+	return &jsn, nil
+}
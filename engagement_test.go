@@ -0,0 +1,114 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+
+	twitterscraper "github.com/n0madic/twitter-scraper"
+)
+
+func TestGetRetweetersFixture(t *testing.T) {
+	scraper, transport := twitterscraper.NewFixtureScraper()
+	var usernames []string
+	for profile := range scraper.GetRetweeters(context.Background(), "1712130000000000001", 10) {
+		if profile.Error != nil {
+			t.Fatal(profile.Error)
+		}
+		usernames = append(usernames, profile.Username)
+	}
+	expected := []string{"TwitterDev", "Support", "XDevelopers"}
+	if len(usernames) != len(expected) {
+		t.Fatalf("Expected retweeters %v, got %v", expected, usernames)
+	}
+	for i := range expected {
+		if usernames[i] != expected[i] {
+			t.Errorf("Expected retweeter #%d %s, got %s", i, expected[i], usernames[i])
+		}
+	}
+	requests := transport.Requests()
+	if len(requests) != 3 {
+		t.Errorf("Expected 3 requests, got %d", len(requests))
+	}
+	if tweetID := twitterscraper.FixtureVariables(requests[0])["tweetId"]; tweetID != "1712130000000000001" {
+		t.Errorf("Expected tweetId 1712130000000000001, got %v", tweetID)
+	}
+}
+
+func TestGetFavoritersFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	var usernames []string
+	for profile := range scraper.GetFavoriters(context.Background(), "1712130000000000001", 10) {
+		if profile.Error != nil {
+			t.Fatal(profile.Error)
+		}
+		usernames = append(usernames, profile.Username)
+	}
+	if len(usernames) != 2 || usernames[0] != "XDevelopers" || usernames[1] != "TwitterDev" {
+		t.Errorf("Unexpected favoriters %v", usernames)
+	}
+}
+
+func TestGetQuoteTweetsFixture(t *testing.T) {
+	scraper, transport := twitterscraper.NewFixtureScraper()
+	var ids []string
+	for tweet := range scraper.GetQuoteTweets(context.Background(), "1712130000000000001", 10) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		if tweet.QuoteRetweetId != "1712130000000000001" {
+			t.Errorf("Expected QuoteRetweetId 1712130000000000001, got %s", tweet.QuoteRetweetId)
+		}
+		ids = append(ids, tweet.ID)
+	}
+	if len(ids) != 2 {
+		t.Fatalf("Expected 2 quote tweets, got %d", len(ids))
+	}
+	variables := twitterscraper.FixtureVariables(transport.Requests()[0])
+	if variables["rawQuery"] != "quoted_tweet_id:1712130000000000001" {
+		t.Errorf("Unexpected rawQuery %v", variables["rawQuery"])
+	}
+}
+
+func TestFetchQuoteTweetsQuoteDepthFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	tweets, _, err := scraper.FetchQuoteTweets("1712130000000000001", 20, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tweets) != 1 || tweets[0].QuotedStatus == nil || tweets[0].QuotedStatus.QuotedStatus == nil {
+		t.Fatalf("Expected quote of the quote, got %v", tweets)
+	}
+
+	scraper.WithQuoteDepth(1)
+	tweets, _, err = scraper.FetchQuoteTweets("1712130000000000001", 20, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	quoted := tweets[0].QuotedStatus
+	if quoted == nil || quoted.ID != "1712130000000000001" || quoted.QuotedStatus != nil {
+		t.Errorf("Expected only one level of quotes, got %v", quoted)
+	}
+}
+
+type engagementContextKey struct{}
+
+func TestEngagementContextFixture(t *testing.T) {
+	scraper, transport := twitterscraper.NewFixtureScraper()
+	ctx := context.WithValue(context.Background(), engagementContextKey{}, "engagement")
+	for range scraper.GetRetweeters(ctx, "1712130000000000001", 10) {
+	}
+	for range scraper.GetFavoriters(ctx, "1712130000000000001", 10) {
+	}
+	for range scraper.GetQuoteTweets(ctx, "1712130000000000001", 10) {
+	}
+
+	requests := transport.Requests()
+	if len(requests) == 0 {
+		t.Fatal("Expected engagement requests")
+	}
+	for _, req := range requests {
+		if req.Context().Value(engagementContextKey{}) != "engagement" {
+			t.Errorf("Expected request %s with the context of the caller", req.URL.Path)
+		}
+	}
+}
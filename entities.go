@@ -0,0 +1,205 @@
+package twitterscraper
+
+import (
+	"html"
+	"sort"
+)
+
+// SegmentType type
+type SegmentType int
+
+const (
+	// SegmentText - plain text
+	SegmentText SegmentType = iota
+	// SegmentMention - @username
+	SegmentMention
+	// SegmentHashtag - #hashtag
+	SegmentHashtag
+	// SegmentCashtag - $SYMBOL
+	SegmentCashtag
+	// SegmentURL - t.co link
+	SegmentURL
+	// SegmentMedia - t.co link of the attached media
+	SegmentMedia
+)
+
+// TextSegment is a part of the tweet text, plain text or an entity.
+// Start and End are offsets in UTF-16 code units of the unescaped text like string
+// indices of JavaScript, so emoji outside of the Basic Multilingual Plane count as two.
+type TextSegment struct {
+	Type        SegmentType
+	Text        string
+	Start       int
+	End         int
+	UserID      string
+	Username    string
+	Tag         string
+	URL         string
+	ExpandedURL string
+	DisplayURL  string
+}
+
+// Mention of the user in the tweet.
+type Mention struct {
+	ID       string
+	Username string
+	Name     string
+	Indices  [2]int
+}
+
+// URL in the tweet, URL is the t.co short link.
+type URL struct {
+	URL         string
+	ExpandedURL string
+	DisplayURL  string
+	Indices     [2]int
+}
+
+// Cashtag in the tweet like $TWTR, Text is without the dollar sign.
+type Cashtag struct {
+	Text    string
+	Indices [2]int
+}
+
+// entities JSON object of the tweet
+type entities struct {
+	Hashtags     []hashtagEntity `json:"hashtags"`
+	Symbols      []hashtagEntity `json:"symbols"`
+	Media        []mediaEntity   `json:"media"`
+	URLs         []urlEntity     `json:"urls"`
+	UserMentions []mentionEntity `json:"user_mentions"`
+}
+
+type hashtagEntity struct {
+	Text    string `json:"text"`
+	Indices []int  `json:"indices"`
+}
+
+type urlEntity struct {
+	DisplayURL  string `json:"display_url"`
+	ExpandedURL string `json:"expanded_url"`
+	URL         string `json:"url"`
+	Indices     []int  `json:"indices"`
+}
+
+type mediaEntity struct {
+	urlEntity
+	IDStr         string `json:"id_str"`
+	MediaURLHttps string `json:"media_url_https"`
+	Type          string `json:"type"`
+}
+
+type mentionEntity struct {
+	IdStr      string `json:"id_str"`
+	Name       string `json:"name"`
+	ScreenName string `json:"screen_name"`
+	Indices    []int  `json:"indices"`
+}
+
+// parseEntities sets hashtags, mentions, URLs and cashtags of the tweet
+func parseEntities(tweet *Tweet, ents entities) {
+	for _, h := range ents.Hashtags {
+		tweet.Hashtags = append(tweet.Hashtags, h.Text)
+	}
+	for _, m := range ents.UserMentions {
+		tweet.Mentions = append(tweet.Mentions, Mention{
+			ID:       m.IdStr,
+			Username: m.ScreenName,
+			Name:     m.Name,
+			Indices:  entityIndices(m.Indices),
+		})
+	}
+	for _, u := range ents.URLs {
+		tweet.URLs = append(tweet.URLs, URL{
+			URL:         u.URL,
+			ExpandedURL: u.ExpandedURL,
+			DisplayURL:  u.DisplayURL,
+			Indices:     entityIndices(u.Indices),
+		})
+	}
+	for _, s := range ents.Symbols {
+		tweet.Cashtags = append(tweet.Cashtags, Cashtag{Text: s.Text, Indices: entityIndices(s.Indices)})
+	}
+}
+
+func entityIndices(indices []int) [2]int {
+	var r [2]int
+	copy(r[:], indices)
+	return r
+}
+
+// parseSegments splits the unescaped text into segments by the entity indices within display text range,
+// leading mentions of the reply and trailing link of the media are outside of it.
+// Entity indices count code points and are converted to UTF-16 offsets of the segments.
+func parseSegments(text string, ents entities, displayTextRange []int) []TextSegment {
+	runes := []rune(html.UnescapeString(text))
+	offsets := utf16Offsets(runes)
+	start, end := 0, len(runes)
+	if len(displayTextRange) == 2 && 0 <= displayTextRange[0] &&
+		displayTextRange[0] <= displayTextRange[1] && displayTextRange[1] <= len(runes) {
+		start, end = displayTextRange[0], displayTextRange[1]
+	}
+
+	var spans []TextSegment
+	add := func(indices []int, segment TextSegment) {
+		if len(indices) != 2 || indices[0] < start || indices[1] > end || indices[0] >= indices[1] {
+			return
+		}
+		segment.Start, segment.End = indices[0], indices[1]
+		spans = append(spans, segment)
+	}
+	for _, m := range ents.UserMentions {
+		add(m.Indices, TextSegment{Type: SegmentMention, UserID: m.IdStr, Username: m.ScreenName})
+	}
+	for _, h := range ents.Hashtags {
+		add(h.Indices, TextSegment{Type: SegmentHashtag, Tag: h.Text})
+	}
+	for _, s := range ents.Symbols {
+		add(s.Indices, TextSegment{Type: SegmentCashtag, Tag: s.Text})
+	}
+	for _, u := range ents.URLs {
+		add(u.Indices, TextSegment{Type: SegmentURL, URL: u.URL, ExpandedURL: u.ExpandedURL, DisplayURL: u.DisplayURL})
+	}
+	// all media of the tweet share the same link
+	if len(ents.Media) > 0 {
+		m := ents.Media[0]
+		add(m.Indices, TextSegment{Type: SegmentMedia, URL: m.URL, ExpandedURL: m.ExpandedURL, DisplayURL: m.DisplayURL})
+	}
+	sort.SliceStable(spans, func(i, j int) bool {
+		return spans[i].Start < spans[j].Start
+	})
+
+	var segments []TextSegment
+	pos := start
+	for _, span := range spans {
+		// overlapping entity
+		if span.Start < pos {
+			continue
+		}
+		if span.Start > pos {
+			segments = append(segments, TextSegment{Type: SegmentText, Text: string(runes[pos:span.Start]), Start: pos, End: span.Start})
+		}
+		span.Text = string(runes[span.Start:span.End])
+		segments = append(segments, span)
+		pos = span.End
+	}
+	if pos < end {
+		segments = append(segments, TextSegment{Type: SegmentText, Text: string(runes[pos:end]), Start: pos, End: end})
+	}
+	for i := range segments {
+		segments[i].Start, segments[i].End = offsets[segments[i].Start], offsets[segments[i].End]
+	}
+	return segments
+}
+
+// utf16Offsets returns UTF-16 offset of every code point and of the end of the text
+func utf16Offsets(runes []rune) []int {
+	offsets := make([]int, len(runes)+1)
+	for i, r := range runes {
+		offsets[i+1] = offsets[i] + 1
+		if r >= 0x10000 {
+			offsets[i+1]++
+		}
+	}
+	return offsets
+}
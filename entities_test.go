@@ -0,0 +1,106 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	twitterscraper "github.com/n0madic/twitter-scraper"
+)
+
+func TestTweetSegmentsFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	tweets, _, err := scraper.FetchTweetsAndReplies("Twitter", 20, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tweets) != 2 {
+		t.Fatalf("Expected 2 tweets, got %d", len(tweets))
+	}
+
+	// leading reply mention and trailing media link are outside of the display text range,
+	// offsets after the emoji are shifted by its second UTF-16 code unit
+	expected := []twitterscraper.TextSegment{
+		{Type: twitterscraper.SegmentText, Text: "Answer & more 🚀 ", Start: 12, End: 29},
+		{Type: twitterscraper.SegmentHashtag, Text: "#golang", Start: 29, End: 36, Tag: "golang"},
+		{Type: twitterscraper.SegmentText, Text: " ", Start: 36, End: 37},
+		{Type: twitterscraper.SegmentCashtag, Text: "$TWTR", Start: 37, End: 42, Tag: "TWTR"},
+		{Type: twitterscraper.SegmentText, Text: " ", Start: 42, End: 43},
+		{Type: twitterscraper.SegmentURL, Text: "https://t.co/link0002", Start: 43, End: 64,
+			URL: "https://t.co/link0002", ExpandedURL: "https://go.dev/blog", DisplayURL: "go.dev/blog"},
+	}
+	if diff := cmp.Diff(expected, tweets[1].Segments); diff != "" {
+		t.Error("Resulting segments does not match the sample", diff)
+	}
+
+	// tweet without entities is a single text segment
+	if len(tweets[0].Segments) != 1 || tweets[0].Segments[0].Text != "Own tweet" {
+		t.Errorf("Unexpected segments %v", tweets[0].Segments)
+	}
+}
+
+func TestMentionSegmentsFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	scraper.WithCookie("auth_token=fixture; ct0=fixture").WithXCsrfToken("fixture")
+	var tweets []twitterscraper.Tweet
+	for tweet := range scraper.GetMentions(context.Background(), 2) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		tweets = append(tweets, tweet.Tweet)
+	}
+	if len(tweets) != 2 {
+		t.Fatalf("Expected 2 mentions, got %d", len(tweets))
+	}
+
+	expected := []twitterscraper.TextSegment{
+		{Type: twitterscraper.SegmentText, Text: "hello", Start: 9, End: 14},
+	}
+	if diff := cmp.Diff(expected, tweets[0].Segments); diff != "" {
+		t.Error("Resulting segments does not match the sample", diff)
+	}
+
+	expected = []twitterscraper.TextSegment{
+		{Type: twitterscraper.SegmentText, Text: "Thanks ", Start: 0, End: 7},
+		{Type: twitterscraper.SegmentMention, Text: "@Twitter", Start: 7, End: 15, UserID: "783214", Username: "Twitter"},
+	}
+	if diff := cmp.Diff(expected, tweets[1].Segments); diff != "" {
+		t.Error("Resulting segments does not match the sample", diff)
+	}
+}
+
+func TestTweetEntitiesFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	tweets, _, err := scraper.FetchTweetsAndReplies("Twitter", 20, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tweets) != 2 {
+		t.Fatalf("Expected 2 tweets, got %d", len(tweets))
+	}
+	reply := tweets[1]
+
+	expectedMentions := []twitterscraper.Mention{
+		{ID: "2244994945", Username: "TwitterDev", Name: "Developers", Indices: [2]int{0, 11}},
+	}
+	if diff := cmp.Diff(expectedMentions, reply.Mentions); diff != "" {
+		t.Error("Resulting mentions does not match the sample", diff)
+	}
+	expectedURLs := []twitterscraper.URL{
+		{URL: "https://t.co/link0002", ExpandedURL: "https://go.dev/blog", DisplayURL: "go.dev/blog", Indices: [2]int{42, 63}},
+	}
+	if diff := cmp.Diff(expectedURLs, reply.URLs); diff != "" {
+		t.Error("Resulting URLs does not match the sample", diff)
+	}
+	expectedCashtags := []twitterscraper.Cashtag{{Text: "TWTR", Indices: [2]int{36, 41}}}
+	if diff := cmp.Diff(expectedCashtags, reply.Cashtags); diff != "" {
+		t.Error("Resulting cashtags does not match the sample", diff)
+	}
+	if diff := cmp.Diff([]string{"golang"}, reply.Hashtags); diff != "" {
+		t.Error("Resulting hashtags does not match the sample", diff)
+	}
+
+	if tweets[0].Mentions != nil || tweets[0].URLs != nil || tweets[0].Cashtags != nil {
+		t.Errorf("Expected no entities, got %v %v %v", tweets[0].Mentions, tweets[0].URLs, tweets[0].Cashtags)
+	}
+}
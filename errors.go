@@ -0,0 +1,12 @@
+package twitterscraper
+
+import "errors"
+
+var (
+	// ErrAuthRequired returned by endpoints which need authenticated session, see WithCookie and WithXCsrfToken
+	ErrAuthRequired = errors.New("authentication required: use WithCookie and WithXCsrfToken")
+	// ErrLikesPrivate returned when likes of the user are not visible to the session
+	ErrLikesPrivate = errors.New("likes are private")
+	// ErrTweetNotFound returned when the tweet is missing in the response, it is deleted or not visible
+	ErrTweetNotFound = errors.New("tweet not found")
+)
@@ -0,0 +1,103 @@
+package twitterscraper
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// FixtureTransport serves GraphQL responses from the testdata directory.
+// The first page of an operation is read from `testdata/<Operation>.json`,
+// following pages from `testdata/<Operation>_<cursor>.json`. Searches are looked up
+// in `testdata/<Operation>_<product>_<query>.json`, with characters of the query other
+// than letters and digits replaced by underscores, and `testdata/<Operation>_<product>.json`
+// first, conversations of a focal tweet in `testdata/<Operation>_<focalTweetId>.json`.
+// REST endpoints like `1.1/broadcasts/show.json` are read from `testdata/broadcasts_show.json`.
+type FixtureTransport struct {
+	mu       sync.Mutex
+	requests []*http.Request
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *FixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.requests = append(t.requests, req)
+	t.mu.Unlock()
+
+	names := []string{path.Base(req.URL.Path)}
+	if strings.HasSuffix(req.URL.Path, ".json") {
+		names[0] = path.Base(path.Dir(req.URL.Path)) + "_" + strings.TrimSuffix(names[0], ".json")
+	}
+	variables := FixtureVariables(req)
+	if product, ok := variables["product"].(string); ok {
+		names = append([]string{names[0] + "_" + product}, names...)
+		if query, ok := variables["rawQuery"].(string); ok {
+			names = append([]string{names[0] + "_" + strings.Map(func(r rune) rune {
+				if unicode.IsLetter(r) || unicode.IsDigit(r) {
+					return r
+				}
+				return '_'
+			}, query)}, names...)
+		}
+	}
+	if focal, ok := variables["focalTweetId"].(string); ok {
+		names = append([]string{names[0] + "_" + focal}, names...)
+	}
+	cursor, _ := variables["cursor"].(string)
+	if cursor == "" {
+		cursor = req.URL.Query().Get("cursor")
+	}
+	if cursor != "" {
+		for i := range names {
+			names[i] += "_" + cursor
+		}
+	}
+
+	status := http.StatusNotFound
+	body := []byte("fixture not found")
+	for _, name := range names {
+		if b, err := ioutil.ReadFile(filepath.Join("testdata", name+".json")); err == nil {
+			status = http.StatusOK
+			body = b
+			break
+		}
+	}
+	return &http.Response{
+		Status:     http.StatusText(status),
+		StatusCode: status,
+		Header:     make(http.Header),
+		Body:       ioutil.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+// Requests returns all requests served by the transport
+func (t *FixtureTransport) Requests() []*http.Request {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]*http.Request(nil), t.requests...)
+}
+
+// FixtureVariables decodes GraphQL variables of the request
+func FixtureVariables(req *http.Request) map[string]interface{} {
+	var variables map[string]interface{}
+	json.Unmarshal([]byte(req.URL.Query().Get("variables")), &variables)
+	return variables
+}
+
+// NewFixtureScraper creates a Scraper object which reads API responses from testdata
+func NewFixtureScraper() (*Scraper, *FixtureTransport) {
+	transport := &FixtureTransport{}
+	s := New()
+	s.client = &http.Client{Transport: transport}
+	s.guestToken = "fixture"
+	s.guestCreatedAt = time.Now()
+	return s, transport
+}
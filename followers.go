@@ -0,0 +1,103 @@
+package twitterscraper
+
+import "context"
+
+// GetFollowers returns channel with followers of a given user.
+// Requires authenticated session, see WithCookie.
+func (s *Scraper) GetFollowers(ctx context.Context, user string, maxProfilesNbr int) <-chan *ProfileResult {
+	return getUserTimeline(ctx, user, maxProfilesNbr, func(user string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+		return s.fetchUserProfiles(ctx, "rRXFSG5vR6drKr5M37YOTw/Followers", user, maxProfilesNbr, cursor)
+	})
+}
+
+// FetchFollowers gets followers of a given user, via the Twitter frontend API.
+// Use returned cursor to resume the enumeration.
+func (s *Scraper) FetchFollowers(user string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+	return s.fetchUserProfiles(context.Background(), "rRXFSG5vR6drKr5M37YOTw/Followers", user, maxProfilesNbr, cursor)
+}
+
+// GetFollowing returns channel with users followed by a given user.
+// Requires authenticated session, see WithCookie.
+func (s *Scraper) GetFollowing(ctx context.Context, user string, maxProfilesNbr int) <-chan *ProfileResult {
+	return getUserTimeline(ctx, user, maxProfilesNbr, func(user string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+		return s.fetchUserProfiles(ctx, "iSicc7LrzWGBgDPL0tM_TQ/Following", user, maxProfilesNbr, cursor)
+	})
+}
+
+// FetchFollowing gets users followed by a given user, via the Twitter frontend API.
+// Use returned cursor to resume the enumeration.
+func (s *Scraper) FetchFollowing(user string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+	return s.fetchUserProfiles(context.Background(), "iSicc7LrzWGBgDPL0tM_TQ/Following", user, maxProfilesNbr, cursor)
+}
+
+// GetVerifiedFollowers returns channel with verified followers of a given user.
+// Requires authenticated session, see WithCookie.
+func (s *Scraper) GetVerifiedFollowers(ctx context.Context, user string, maxProfilesNbr int) <-chan *ProfileResult {
+	return getUserTimeline(ctx, user, maxProfilesNbr, func(user string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+		return s.fetchUserProfiles(ctx, "OTzIlm3LjFNQWyvWq9R4cQ/BlueVerifiedFollowers", user, maxProfilesNbr, cursor)
+	})
+}
+
+// FetchVerifiedFollowers gets verified followers of a given user, via the Twitter frontend API.
+// Use returned cursor to resume the enumeration.
+func (s *Scraper) FetchVerifiedFollowers(user string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+	return s.fetchUserProfiles(context.Background(), "OTzIlm3LjFNQWyvWq9R4cQ/BlueVerifiedFollowers", user, maxProfilesNbr, cursor)
+}
+
+// GetFollowersYouKnow returns channel with followers of a given user which are followed by the session user.
+// Requires authenticated session, see WithCookie.
+func (s *Scraper) GetFollowersYouKnow(ctx context.Context, user string, maxProfilesNbr int) <-chan *ProfileResult {
+	return getUserTimeline(ctx, user, maxProfilesNbr, func(user string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+		return s.fetchUserProfiles(ctx, "9wBh-MEFoTAjs25z3Ci_3g/FollowersYouKnow", user, maxProfilesNbr, cursor)
+	})
+}
+
+// FetchFollowersYouKnow gets followers of a given user which are followed by the session user, via the Twitter frontend API.
+// Use returned cursor to resume the enumeration.
+func (s *Scraper) FetchFollowersYouKnow(user string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+	return s.fetchUserProfiles(context.Background(), "9wBh-MEFoTAjs25z3Ci_3g/FollowersYouKnow", user, maxProfilesNbr, cursor)
+}
+
+// fetchUserProfiles gets users of GraphQL user list operation for a given user
+func (s *Scraper) fetchUserProfiles(ctx context.Context, operation string, user string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+	if !s.IsLoggedIn() {
+		return nil, "", ErrAuthRequired
+	}
+	if maxProfilesNbr > 50 {
+		maxProfilesNbr = 50
+	}
+
+	userID, err := s.GetUserIDByScreenName(user)
+	if err != nil {
+		return nil, "", err
+	}
+
+	variables := map[string]interface{}{
+		"userId":                 userID,
+		"count":                  maxProfilesNbr,
+		"includePromotedContent": false,
+	}
+	if cursor != "" {
+		variables["cursor"] = cursor
+	}
+
+	req, err := s.newGraphQLRequest(operation, variables)
+	if err != nil {
+		return nil, "", err
+	}
+	req = req.WithContext(ctx)
+
+	var jsn userTimeline
+	err = s.RequestAPI(req, &jsn)
+	if err != nil {
+		return nil, "", err
+	}
+
+	timeline := &jsn.Data.User.Result.Timeline.Timeline
+	if len(jsn.Errors) > 0 && len(timeline.Instructions) == 0 {
+		return nil, "", jsn.Errors[0]
+	}
+
+	profiles, nextCursor := timeline.parseUsers()
+	return profiles, nextCursor, nil
+}
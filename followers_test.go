@@ -0,0 +1,95 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	twitterscraper "github.com/n0madic/twitter-scraper"
+)
+
+func TestGetFollowersFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	for profile := range scraper.GetFollowers(context.Background(), "Twitter", 10) {
+		if !errors.Is(profile.Error, twitterscraper.ErrAuthRequired) {
+			t.Errorf("Expected ErrAuthRequired, got %v", profile.Error)
+		}
+	}
+
+	scraper.WithCookie("auth_token=fixture; ct0=fixture").WithXCsrfToken("fixture")
+	var usernames []string
+	for profile := range scraper.GetFollowers(context.Background(), "Twitter", 10) {
+		if profile.Error != nil {
+			t.Fatal(profile.Error)
+		}
+		usernames = append(usernames, profile.Username)
+	}
+	expected := []string{"TwitterDev", "Support", "XDevelopers"}
+	if len(usernames) != len(expected) {
+		t.Fatalf("Expected followers %v, got %v", expected, usernames)
+	}
+	for i := range expected {
+		if usernames[i] != expected[i] {
+			t.Errorf("Expected follower #%d %s, got %s", i, expected[i], usernames[i])
+		}
+	}
+}
+
+func TestFetchFollowersResumeFixture(t *testing.T) {
+	scraper, transport := twitterscraper.NewFixtureScraper()
+	scraper.WithCookie("auth_token=fixture; ct0=fixture").WithXCsrfToken("fixture")
+	profiles, cursor, err := scraper.FetchFollowers("Twitter", 20, "followers2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(profiles) != 2 || profiles[1].UserID != "6253282" {
+		t.Errorf("Unexpected followers page %v", profiles)
+	}
+	if cursor != "followers3" {
+		t.Errorf("Expected cursor followers3, got %s", cursor)
+	}
+	requests := transport.Requests()
+	if variables := twitterscraper.FixtureVariables(requests[len(requests)-1]); variables["userId"] != "783214" {
+		t.Errorf("Expected userId 783214, got %v", variables["userId"])
+	}
+}
+
+func TestGetFollowingFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	scraper.WithCookie("auth_token=fixture; ct0=fixture").WithXCsrfToken("fixture")
+	count := 0
+	for profile := range scraper.GetFollowing(context.Background(), "Twitter", 10) {
+		if profile.Error != nil {
+			t.Fatal(profile.Error)
+		}
+		if profile.Username != "XDevelopers" {
+			t.Errorf("Unexpected following %s", profile.Username)
+		}
+		count++
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 following, got %d", count)
+	}
+}
+
+type followersContextKey struct{}
+
+func TestFollowersContextFixture(t *testing.T) {
+	scraper, transport := twitterscraper.NewFixtureScraper()
+	scraper.WithCookie("auth_token=fixture; ct0=fixture").WithXCsrfToken("fixture")
+	ctx := context.WithValue(context.Background(), followersContextKey{}, "followers")
+	for range scraper.GetFollowers(ctx, "Twitter", 10) {
+	}
+	for range scraper.GetFollowing(ctx, "Twitter", 10) {
+	}
+
+	for _, req := range transport.Requests() {
+		if strings.HasSuffix(req.URL.Path, "/UserByScreenName") {
+			continue
+		}
+		if req.Context().Value(followersContextKey{}) != "followers" {
+			t.Errorf("Expected request %s with the context of the caller", req.URL.Path)
+		}
+	}
+}
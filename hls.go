@@ -0,0 +1,324 @@
+package twitterscraper
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HLSPlaylist is a master playlist with variants or a media playlist with segments.
+type HLSPlaylist struct {
+	URL            string
+	Variants       []HLSVariant
+	Segments       []HLSSegment
+	InitSegment    string
+	TargetDuration time.Duration
+	IsEncrypted    bool
+	IsEnded        bool
+}
+
+// HLSVariant is a rendition of the master playlist.
+type HLSVariant struct {
+	Bandwidth        int
+	AverageBandwidth int
+	Width            int
+	Height           int
+	Codecs           string
+	URL              string
+}
+
+// HLSSegment of the media playlist.
+type HLSSegment struct {
+	Duration time.Duration
+	URL      string
+}
+
+// IsMaster reports whether the playlist lists variants instead of segments.
+func (p *HLSPlaylist) IsMaster() bool {
+	return len(p.Variants) > 0
+}
+
+// Duration returns total duration of the segments.
+func (p *HLSPlaylist) Duration() time.Duration {
+	var d time.Duration
+	for _, segment := range p.Segments {
+		d += segment.Duration
+	}
+	return d
+}
+
+// BestVariant returns variant with the highest bandwidth.
+func (p *HLSPlaylist) BestVariant() (HLSVariant, bool) {
+	return p.VariantByBandwidth(0)
+}
+
+// VariantByBandwidth returns variant with the highest bandwidth not exceeding maxBandwidth,
+// zero maxBandwidth means no limit.
+func (p *HLSPlaylist) VariantByBandwidth(maxBandwidth int) (HLSVariant, bool) {
+	var best HLSVariant
+	found := false
+	for _, v := range p.Variants {
+		if maxBandwidth > 0 && v.Bandwidth > maxBandwidth {
+			continue
+		}
+		if !found || v.Bandwidth > best.Bandwidth {
+			best = v
+			found = true
+		}
+	}
+	return best, found
+}
+
+// VariantByResolution returns variant with the highest resolution not exceeding maxHeight,
+// zero or negative maxHeight means no limit. Variants of the same resolution are chosen
+// by the highest bandwidth.
+func (p *HLSPlaylist) VariantByResolution(maxHeight int) (HLSVariant, bool) {
+	var best HLSVariant
+	found := false
+	for _, v := range p.Variants {
+		if maxHeight > 0 && v.Height > maxHeight {
+			continue
+		}
+		if !found || v.Height > best.Height || v.Height == best.Height && v.Bandwidth > best.Bandwidth {
+			best = v
+			found = true
+		}
+	}
+	return best, found
+}
+
+// ParseHLSPlaylist parses M3U8 playlist, URIs are resolved relative to playlistURL.
+func ParseHLSPlaylist(r io.Reader, playlistURL string) (*HLSPlaylist, error) {
+	base, err := url.Parse(playlistURL)
+	if err != nil {
+		return nil, err
+	}
+
+	playlist := &HLSPlaylist{URL: playlistURL}
+	scanner := bufio.NewScanner(r)
+	header := false
+	var variant *HLSVariant
+	var segmentDuration time.Duration
+	isSegment := false
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if !header {
+			if line != "#EXTM3U" {
+				return nil, errors.New("not a M3U8 playlist")
+			}
+			header = true
+			continue
+		}
+
+		tag, value := line, ""
+		if i := strings.Index(line, ":"); i >= 0 && strings.HasPrefix(line, "#") {
+			tag, value = line[:i], line[i+1:]
+		}
+		switch {
+		case tag == "#EXT-X-STREAM-INF":
+			attrs := parseHLSAttributes(value)
+			variant = &HLSVariant{Codecs: attrs["CODECS"]}
+			variant.Bandwidth, _ = strconv.Atoi(attrs["BANDWIDTH"])
+			variant.AverageBandwidth, _ = strconv.Atoi(attrs["AVERAGE-BANDWIDTH"])
+			if res := strings.SplitN(attrs["RESOLUTION"], "x", 2); len(res) == 2 {
+				variant.Width, _ = strconv.Atoi(res[0])
+				variant.Height, _ = strconv.Atoi(res[1])
+			}
+		case tag == "#EXTINF":
+			seconds, err := strconv.ParseFloat(strings.SplitN(value, ",", 2)[0], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid segment duration %q", value)
+			}
+			segmentDuration = time.Duration(seconds * float64(time.Second))
+			isSegment = true
+		case tag == "#EXT-X-TARGETDURATION":
+			seconds, _ := strconv.Atoi(value)
+			playlist.TargetDuration = time.Duration(seconds) * time.Second
+		case tag == "#EXT-X-MAP":
+			playlist.InitSegment = resolveHLSURI(base, parseHLSAttributes(value)["URI"])
+		case tag == "#EXT-X-KEY":
+			if method := parseHLSAttributes(value)["METHOD"]; method != "" && method != "NONE" {
+				playlist.IsEncrypted = true
+			}
+		case tag == "#EXT-X-ENDLIST":
+			playlist.IsEnded = true
+		case strings.HasPrefix(line, "#"):
+			// unsupported tag or comment
+		case variant != nil:
+			variant.URL = resolveHLSURI(base, line)
+			playlist.Variants = append(playlist.Variants, *variant)
+			variant = nil
+		case isSegment:
+			playlist.Segments = append(playlist.Segments, HLSSegment{
+				Duration: segmentDuration,
+				URL:      resolveHLSURI(base, line),
+			})
+			isSegment = false
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !header {
+		return nil, errors.New("not a M3U8 playlist")
+	}
+	return playlist, nil
+}
+
+// parseHLSAttributes parses attribute list like BANDWIDTH=832000,CODECS="mp4a.40.2,avc1.4d001f"
+func parseHLSAttributes(s string) map[string]string {
+	attrs := make(map[string]string)
+	for s != "" {
+		eq := strings.Index(s, "=")
+		if eq < 0 {
+			break
+		}
+		key := strings.TrimSpace(s[:eq])
+		s = s[eq+1:]
+
+		var value string
+		if strings.HasPrefix(s, `"`) {
+			end := strings.Index(s[1:], `"`)
+			if end < 0 {
+				value, s = s[1:], ""
+			} else {
+				value, s = s[1:end+1], s[end+2:]
+			}
+			s = strings.TrimPrefix(s, ",")
+		} else if comma := strings.Index(s, ","); comma >= 0 {
+			value, s = s[:comma], s[comma+1:]
+		} else {
+			value, s = s, ""
+		}
+		attrs[key] = value
+	}
+	return attrs
+}
+
+func resolveHLSURI(base *url.URL, uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+	return base.ResolveReference(u).String()
+}
+
+// GetHLSPlaylist fetches and parses M3U8 playlist via the Scraper HTTP client.
+func (s *Scraper) GetHLSPlaylist(ctx context.Context, playlistURL string) (*HLSPlaylist, error) {
+	resp, err := s.getHLS(ctx, s.client, playlistURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return ParseHLSPlaylist(resp.Body, playlistURL)
+}
+
+// DownloadHLS downloads the stream and writes segments concatenated into w, like single MPEG-TS file.
+// A master playlist is resolved to the variant with the highest bandwidth.
+// Segments are downloaded by concurrency requests in parallel.
+func (s *Scraper) DownloadHLS(ctx context.Context, playlist *HLSPlaylist, w io.Writer, concurrency int) error {
+	if playlist.IsMaster() {
+		variant, _ := playlist.BestVariant()
+		var err error
+		playlist, err = s.GetHLSPlaylist(ctx, variant.URL)
+		if err != nil {
+			return err
+		}
+	}
+	if playlist.IsEncrypted {
+		return errors.New("encrypted HLS stream is not supported")
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	// segments can take longer than the API requests, they are limited by context only
+	client := *s.client
+	client.Timeout = 0
+
+	urls := make([]string, 0, len(playlist.Segments)+1)
+	if playlist.InitSegment != "" {
+		urls = append(urls, playlist.InitSegment)
+	}
+	for _, segment := range playlist.Segments {
+		urls = append(urls, segment.URL)
+	}
+
+	type segmentResult struct {
+		data []byte
+		err  error
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// not more than concurrency segments are downloaded or wait to be written
+	results := make([]chan segmentResult, len(urls))
+	for i := range results {
+		results[i] = make(chan segmentResult, 1)
+	}
+	sem := make(chan struct{}, concurrency)
+	go func() {
+		for i, u := range urls {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			go func(i int, u string) {
+				resp, err := s.getHLS(ctx, &client, u)
+				if err != nil {
+					results[i] <- segmentResult{err: err}
+					return
+				}
+				defer resp.Body.Close()
+				data, err := ioutil.ReadAll(resp.Body)
+				results[i] <- segmentResult{data: data, err: err}
+			}(i, u)
+		}
+	}()
+
+	for i := range urls {
+		var result segmentResult
+		select {
+		case result = <-results[i]:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		<-sem
+		if result.err != nil {
+			return result.err
+		}
+		if _, err := w.Write(result.data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Scraper) getHLS(ctx context.Context, client *http.Client, u string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("get %s: response status %s", u, resp.Status)
+	}
+	return resp, nil
+}
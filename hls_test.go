@@ -0,0 +1,200 @@
+package twitterscraper_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/n0madic/twitter-scraper"
+)
+
+// newHLSServer serves fixture playlists, content of each segment is its path
+func newHLSServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch path.Ext(r.URL.Path) {
+		case ".m3u8":
+			http.ServeFile(w, r, filepath.Join("testdata", "hls", path.Base(r.URL.Path)))
+		case ".ts":
+			if strings.HasSuffix(r.URL.Path, "640x360/2.ts") {
+				http.NotFound(w, r)
+				return
+			}
+			// the first segment comes last to check the order of concatenation
+			if path.Base(r.URL.Path) == "0.ts" {
+				time.Sleep(50 * time.Millisecond)
+			}
+			w.Write([]byte(r.URL.Path + "\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func hlsSegments(paths ...string) []byte {
+	return []byte(strings.Join(paths, "\n") + "\n")
+}
+
+func TestParseHLSPlaylist(t *testing.T) {
+	f, err := os.Open("testdata/hls/master.m3u8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	master, err := twitterscraper.ParseHLSPlaylist(f, "https://video.twimg.com/ext_tw_video/1002/pu/pl/master.m3u8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !master.IsMaster() || len(master.Variants) != 3 {
+		t.Fatalf("Expected master playlist with 3 variants, got %v", master.Variants)
+	}
+	high := master.Variants[2]
+	if high.Bandwidth != 2600000 || high.AverageBandwidth != 2176000 || high.Width != 1280 || high.Height != 720 ||
+		high.Codecs != "mp4a.40.2,avc1.640020" ||
+		high.URL != "https://video.twimg.com/ext_tw_video/1002/pu/pl/1280x720/high.m3u8" {
+		t.Errorf("Unexpected variant %+v", high)
+	}
+
+	if v, ok := master.BestVariant(); !ok || v.Height != 720 {
+		t.Errorf("Expected best variant 720p, got %+v", v)
+	}
+	if v, ok := master.VariantByBandwidth(1000000); !ok || v.Height != 360 {
+		t.Errorf("Expected variant 360p by bandwidth, got %+v", v)
+	}
+	if v, ok := master.VariantByResolution(480); !ok || v.Height != 360 {
+		t.Errorf("Expected variant 360p by resolution, got %+v", v)
+	}
+	if v, ok := master.VariantByResolution(144); ok {
+		t.Errorf("Expected no variant below 144p, got %+v", v)
+	}
+	if v, ok := master.VariantByResolution(0); !ok || v.Height != 720 {
+		t.Errorf("Expected variant 720p without resolution limit, got %+v", v)
+	}
+
+	b, err := ioutil.ReadFile("testdata/hls/high.m3u8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	media, err := twitterscraper.ParseHLSPlaylist(bytes.NewReader(b), high.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if media.IsMaster() || len(media.Segments) != 4 || !media.IsEnded || media.TargetDuration != 3*time.Second {
+		t.Fatalf("Unexpected media playlist %+v", media)
+	}
+	if media.Duration() != 10500*time.Millisecond {
+		t.Errorf("Expected duration 10.5s, got %s", media.Duration())
+	}
+	if media.Segments[0].URL != "https://video.twimg.com/ext_tw_video/1002/pu/vid/1280x720/0.ts" ||
+		media.Segments[3].URL != "https://video.twimg.com/ext_tw_video/1002/pu/pl/1280x720/3.ts" {
+		t.Errorf("Unexpected segments %v", media.Segments)
+	}
+
+	_, err = twitterscraper.ParseHLSPlaylist(strings.NewReader("<html></html>"), high.URL)
+	if err == nil {
+		t.Error("Expected error for not a playlist")
+	}
+}
+
+func TestParseHLSPlaylistAttributes(t *testing.T) {
+	playlist := `#EXTM3U
+#EXT-X-KEY:METHOD=AES-128,URI="https://example.com/key"
+#EXT-X-MAP:URI="init.mp4"
+#EXTINF:2.5,title
+segment.m4s
+`
+	media, err := twitterscraper.ParseHLSPlaylist(strings.NewReader(playlist), "https://example.com/pl/media.m3u8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !media.IsEncrypted || media.InitSegment != "https://example.com/pl/init.mp4" {
+		t.Errorf("Unexpected media playlist %+v", media)
+	}
+	if len(media.Segments) != 1 || media.Segments[0].Duration != 2500*time.Millisecond {
+		t.Errorf("Unexpected segments %v", media.Segments)
+	}
+}
+
+func TestDownloadHLS(t *testing.T) {
+	srv := newHLSServer()
+	defer srv.Close()
+
+	scraper := twitterscraper.New()
+	master, err := scraper.GetHLSPlaylist(context.Background(), srv.URL+"/ext_tw_video/1002/pu/pl/master.m3u8")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	err = scraper.DownloadHLS(context.Background(), master, &buf, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := hlsSegments(
+		"/ext_tw_video/1002/pu/vid/1280x720/0.ts",
+		"/ext_tw_video/1002/pu/vid/1280x720/1.ts",
+		"/ext_tw_video/1002/pu/vid/1280x720/2.ts",
+		"/ext_tw_video/1002/pu/pl/1280x720/3.ts",
+	)
+	if !bytes.Equal(buf.Bytes(), expected) {
+		t.Errorf("Unexpected stream\n%s", buf.Bytes())
+	}
+
+	variant, _ := master.VariantByResolution(360)
+	media, err := scraper.GetHLSPlaylist(context.Background(), variant.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = scraper.DownloadHLS(context.Background(), media, ioutil.Discard, 2)
+	if err == nil {
+		t.Error("Expected error for missing segment")
+	}
+}
+
+func TestMediaDownloaderHLS(t *testing.T) {
+	srv := newHLSServer()
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "media")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	video := twitterscraper.Media{IDStr: "1002", Type: "video"}
+	video.VideoInfo.Variants = []struct {
+		Bitrate     int    `json:"bitrate,omitempty"`
+		ContentType string `json:"content_type"`
+		URL         string `json:"url"`
+	}{
+		{ContentType: "application/x-mpegURL", URL: srv.URL + "/ext_tw_video/1002/pu/pl/master.m3u8"},
+	}
+
+	files, err := twitterscraper.New().NewMediaDownloader(dir).DownloadMedia(context.Background(), "1", video)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := hlsSegments(
+		"/ext_tw_video/1002/pu/vid/1280x720/0.ts",
+		"/ext_tw_video/1002/pu/vid/1280x720/1.ts",
+		"/ext_tw_video/1002/pu/vid/1280x720/2.ts",
+		"/ext_tw_video/1002/pu/pl/1280x720/3.ts",
+	)
+	if len(files) != 1 || files[0].File != sha256Hex(expected)+".ts" {
+		t.Fatalf("Unexpected files %v", files)
+	}
+	b, err := ioutil.ReadFile(filepath.Join(dir, files[0].File))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, expected) {
+		t.Errorf("Unexpected content of the video\n%s", b)
+	}
+}
@@ -0,0 +1,162 @@
+package twitterscraper
+
+import (
+	"context"
+	"strconv"
+)
+
+// HomeTimelineMode type
+type HomeTimelineMode int
+
+const (
+	// HomeForYou - recommended tweets
+	HomeForYou HomeTimelineMode = iota
+	// HomeFollowing - latest tweets of followed accounts
+	HomeFollowing
+)
+
+// homeTimeline JSON object returned by the home timelines and bookmarks
+type homeTimeline struct {
+	Errors []Err `json:"errors"`
+	Data   struct {
+		Home struct {
+			HomeTimelineUrt timelineV2 `json:"home_timeline_urt"`
+		} `json:"home"`
+		BookmarkTimelineV2 struct {
+			Timeline timelineV2 `json:"timeline"`
+		} `json:"bookmark_timeline_v2"`
+	} `json:"data"`
+}
+
+// GetHomeTimeline returns channel with tweets of the home timeline of the authenticated user.
+func (s *Scraper) GetHomeTimeline(ctx context.Context, mode HomeTimelineMode, maxTweetsNbr int) <-chan *TweetResult {
+	return getTweetTimeline(ctx, "", maxTweetsNbr, func(_ string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+		return s.fetchHomeTimeline(ctx, mode, maxTweetsNbr, cursor)
+	})
+}
+
+// FetchHomeTimeline gets tweets of the home timeline of the authenticated user, via the Twitter frontend API.
+func (s *Scraper) FetchHomeTimeline(mode HomeTimelineMode, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	return s.fetchHomeTimeline(context.Background(), mode, maxTweetsNbr, cursor)
+}
+
+// fetchHomeTimeline gets tweets of the home timeline with the context
+func (s *Scraper) fetchHomeTimeline(ctx context.Context, mode HomeTimelineMode, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	operation := "HJFjzBgCs16TqxewQOeLNg/HomeTimeline"
+	if mode == HomeFollowing {
+		operation = "DiTkXJgLqBBxCs7zaYsbtA/HomeLatestTimeline"
+	}
+
+	jsn, err := s.getHomeTimeline(ctx, operation, maxTweetsNbr, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	tweets, nextCursor := jsn.Data.Home.HomeTimelineUrt.parseTweets()
+	return s.prepareTweets(tweets), nextCursor, nil
+}
+
+// GetBookmarks returns channel with bookmarked tweets of the authenticated user.
+func (s *Scraper) GetBookmarks(ctx context.Context, maxTweetsNbr int) <-chan *TweetResult {
+	return getTweetTimeline(ctx, "", maxTweetsNbr, func(_ string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+		return s.fetchBookmarks(ctx, maxTweetsNbr, cursor)
+	})
+}
+
+// FetchBookmarks gets bookmarked tweets of the authenticated user, via the Twitter frontend API.
+func (s *Scraper) FetchBookmarks(maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	return s.fetchBookmarks(context.Background(), maxTweetsNbr, cursor)
+}
+
+// fetchBookmarks gets bookmarked tweets with the context
+func (s *Scraper) fetchBookmarks(ctx context.Context, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	jsn, err := s.getHomeTimeline(ctx, "j5KExFXtSWj8HjRui17ydA/Bookmarks", maxTweetsNbr, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	tweets, nextCursor := jsn.Data.BookmarkTimelineV2.Timeline.parseTweets()
+	return s.prepareTweets(tweets), nextCursor, nil
+}
+
+// GetMentions returns channel with tweets mentioning the authenticated user, as shown in notifications.
+func (s *Scraper) GetMentions(ctx context.Context, maxTweetsNbr int) <-chan *TweetResult {
+	return getTweetTimeline(ctx, "", maxTweetsNbr, func(_ string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+		return s.fetchMentions(ctx, maxTweetsNbr, cursor)
+	})
+}
+
+// FetchMentions gets tweets mentioning the authenticated user, via the Twitter frontend API.
+func (s *Scraper) FetchMentions(maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	return s.fetchMentions(context.Background(), maxTweetsNbr, cursor)
+}
+
+// fetchMentions gets tweets mentioning the authenticated user with the context
+func (s *Scraper) fetchMentions(ctx context.Context, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	if !s.IsLoggedIn() {
+		return nil, "", ErrAuthRequired
+	}
+	if maxTweetsNbr > 50 {
+		maxTweetsNbr = 50
+	}
+
+	req, err := s.newRequest("GET", "https://twitter.com/i/api/2/notifications/mentions.json")
+	if err != nil {
+		return nil, "", err
+	}
+
+	q := req.URL.Query()
+	q.Add("count", strconv.Itoa(maxTweetsNbr))
+	if cursor != "" {
+		q.Add("cursor", cursor)
+	}
+	req.URL.RawQuery = q.Encode()
+	req = req.WithContext(ctx)
+
+	var jsn timeline
+	err = s.RequestAPI(req, &jsn)
+	if err != nil {
+		return nil, "", err
+	}
+
+	tweets, nextCursor := jsn.parseTweets()
+	return s.prepareTweets(tweets), nextCursor, nil
+}
+
+// getHomeTimeline gets GraphQL timeline operation of the authenticated user
+func (s *Scraper) getHomeTimeline(ctx context.Context, operation string, maxTweetsNbr int, cursor string) (*homeTimeline, error) {
+	if !s.IsLoggedIn() {
+		return nil, ErrAuthRequired
+	}
+	if maxTweetsNbr > 50 {
+		maxTweetsNbr = 50
+	}
+
+	variables := map[string]interface{}{
+		"count":                  maxTweetsNbr,
+		"includePromotedContent": false,
+		"latestControlAvailable": true,
+		"requestContext":         "launch",
+		"withCommunity":          true,
+	}
+	if cursor != "" {
+		variables["cursor"] = cursor
+	}
+
+	req, err := s.newGraphQLRequest(operation, variables)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	var jsn homeTimeline
+	err = s.RequestAPI(req, &jsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(jsn.Errors) > 0 &&
+		len(jsn.Data.Home.HomeTimelineUrt.Instructions) == 0 &&
+		len(jsn.Data.BookmarkTimelineV2.Timeline.Instructions) == 0 {
+		return nil, jsn.Errors[0]
+	}
+	return &jsn, nil
+}
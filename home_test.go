@@ -0,0 +1,115 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	twitterscraper "github.com/n0madic/twitter-scraper"
+)
+
+func TestGetHomeTimelineFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	for tweet := range scraper.GetHomeTimeline(context.Background(), twitterscraper.HomeForYou, 10) {
+		if !errors.Is(tweet.Error, twitterscraper.ErrAuthRequired) {
+			t.Errorf("Expected ErrAuthRequired, got %v", tweet.Error)
+		}
+	}
+
+	scraper.WithCookie("auth_token=fixture; ct0=fixture").WithXCsrfToken("fixture")
+	for mode, expectedIDs := range map[twitterscraper.HomeTimelineMode][]string{
+		twitterscraper.HomeForYou:    {"1713000000000000001", "1713000000000000002"},
+		twitterscraper.HomeFollowing: {"1713000000000000003"},
+	} {
+		var ids []string
+		for tweet := range scraper.GetHomeTimeline(context.Background(), mode, 10) {
+			if tweet.Error != nil {
+				t.Fatal(tweet.Error)
+			}
+			ids = append(ids, tweet.ID)
+		}
+		if len(ids) != len(expectedIDs) {
+			t.Fatalf("Expected %d tweets in mode %d, got %v", len(expectedIDs), mode, ids)
+		}
+		for i, id := range expectedIDs {
+			if ids[i] != id {
+				t.Errorf("Expected tweet #%d ID %s, got %s", i, id, ids[i])
+			}
+		}
+	}
+}
+
+func TestGetBookmarksFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	_, _, err := scraper.FetchBookmarks(20, "")
+	if !errors.Is(err, twitterscraper.ErrAuthRequired) {
+		t.Errorf("Expected ErrAuthRequired, got %v", err)
+	}
+
+	scraper.WithCookie("auth_token=fixture; ct0=fixture").WithXCsrfToken("fixture")
+	var ids []string
+	for tweet := range scraper.GetBookmarks(context.Background(), 10) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		ids = append(ids, tweet.ID)
+	}
+	if len(ids) != 2 || ids[0] != "1713000000000000004" || ids[1] != "1713000000000000005" {
+		t.Errorf("Unexpected bookmarks %v", ids)
+	}
+}
+
+func TestGetMentionsFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	_, _, err := scraper.FetchMentions(20, "")
+	if !errors.Is(err, twitterscraper.ErrAuthRequired) {
+		t.Errorf("Expected ErrAuthRequired, got %v", err)
+	}
+
+	scraper.WithCookie("auth_token=fixture; ct0=fixture").WithXCsrfToken("fixture")
+	var tweets []twitterscraper.Tweet
+	for tweet := range scraper.GetMentions(context.Background(), 10) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		tweets = append(tweets, tweet.Tweet)
+	}
+	if len(tweets) != 2 {
+		t.Fatalf("Expected 2 mentions, got %d", len(tweets))
+	}
+	if tweets[0].Username != "TwitterDev" || !tweets[0].IsReply || tweets[0].ReplyingTo != "1712200000000000001" {
+		t.Errorf("Unexpected mention %s %v %s", tweets[0].Username, tweets[0].IsReply, tweets[0].ReplyingTo)
+	}
+	if tweets[0].PermanentURL != "https://twitter.com/TwitterDev/status/1713000000000000006" {
+		t.Errorf("Unexpected PermanentURL %s", tweets[0].PermanentURL)
+	}
+	if len(tweets[1].Mentions) != 1 || tweets[1].Mentions[0].ID != "783214" ||
+		tweets[1].Mentions[0].Username != "Twitter" || tweets[1].Mentions[0].Indices != [2]int{7, 15} ||
+		len(tweets[1].Hashtags) != 1 || tweets[1].Timestamp != 1696924800 {
+		t.Errorf("Unexpected mention %v %v %d", tweets[1].Mentions, tweets[1].Hashtags, tweets[1].Timestamp)
+	}
+}
+
+type homeContextKey struct{}
+
+func TestHomeContextFixture(t *testing.T) {
+	scraper, transport := twitterscraper.NewFixtureScraper()
+	scraper.WithCookie("auth_token=fixture; ct0=fixture").WithXCsrfToken("fixture")
+	ctx := context.WithValue(context.Background(), homeContextKey{}, "home")
+	for range scraper.GetHomeTimeline(ctx, twitterscraper.HomeForYou, 10) {
+	}
+	for range scraper.GetBookmarks(ctx, 10) {
+	}
+	for range scraper.GetMentions(ctx, 10) {
+	}
+
+	requests := transport.Requests()
+	if len(requests) == 0 {
+		t.Fatal("Expected home requests")
+	}
+	for _, req := range requests {
+		if req.Context().Value(homeContextKey{}) != "home" {
+			t.Errorf("Expected request %s with the context of the caller", req.URL.Path)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package twitterscraper
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// List of twitter users.
+type List struct {
+	ID              string
+	Name            string
+	Description     string
+	Banner          string
+	Created         *time.Time
+	IsPrivate       bool
+	MemberCount     int
+	SubscriberCount int
+	Owner           Profile
+	URL             string
+}
+
+type listResult struct {
+	IDStr           string `json:"id_str"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	CreatedAt       int64  `json:"created_at"`
+	MemberCount     int    `json:"member_count"`
+	SubscriberCount int    `json:"subscriber_count"`
+	Mode            string `json:"mode"`
+	UserResults     struct {
+		Result UserResult `json:"result"`
+	} `json:"user_results"`
+	DefaultBannerMedia listBanner `json:"default_banner_media"`
+	CustomBannerMedia  listBanner `json:"custom_banner_media"`
+}
+
+type listBanner struct {
+	MediaInfo struct {
+		OriginalImgURL string `json:"original_img_url"`
+	} `json:"media_info"`
+}
+
+// listTimeline JSON object returned by the list operations
+type listTimeline struct {
+	Errors []Err `json:"errors"`
+	Data   struct {
+		List struct {
+			listResult
+			MembersTimeline struct {
+				Timeline timelineV2 `json:"timeline"`
+			} `json:"members_timeline"`
+			SubscribersTimeline struct {
+				Timeline timelineV2 `json:"timeline"`
+			} `json:"subscribers_timeline"`
+			TweetsTimeline struct {
+				Timeline timelineV2 `json:"timeline"`
+			} `json:"tweets_timeline"`
+		} `json:"list"`
+	} `json:"data"`
+}
+
+// GetList return list metadata.
+func (s *Scraper) GetList(ctx context.Context, listID string) (List, error) {
+	jsn, err := s.fetchListGraphQL(ctx, "Tzkkg-NaBi_y1aAUUb6_eQ/ListByRestId", listID, 0, "")
+	if err != nil {
+		return List{}, err
+	}
+	if jsn.Data.List.IDStr == "" {
+		return List{}, fmt.Errorf("list %s not found", listID)
+	}
+	return parseList(jsn.Data.List.listResult), nil
+}
+
+// GetListMembers returns channel with members of a given list.
+func (s *Scraper) GetListMembers(ctx context.Context, listID string, maxProfilesNbr int) <-chan *ProfileResult {
+	return getUserTimeline(ctx, listID, maxProfilesNbr, func(listID string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+		return s.fetchListMembers(ctx, listID, maxProfilesNbr, cursor)
+	})
+}
+
+// FetchListMembers gets members of a given list, via the Twitter frontend API.
+func (s *Scraper) FetchListMembers(listID string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+	return s.fetchListMembers(context.Background(), listID, maxProfilesNbr, cursor)
+}
+
+// fetchListMembers gets members of a given list with the context
+func (s *Scraper) fetchListMembers(ctx context.Context, listID string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+	jsn, err := s.fetchListGraphQL(ctx, "BQp2IEYkgxuSxqbTAr1e1g/ListMembers", listID, maxProfilesNbr, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	profiles, nextCursor := jsn.Data.List.MembersTimeline.Timeline.parseUsers()
+	return profiles, nextCursor, nil
+}
+
+// GetListSubscribers returns channel with subscribers of a given list.
+func (s *Scraper) GetListSubscribers(ctx context.Context, listID string, maxProfilesNbr int) <-chan *ProfileResult {
+	return getUserTimeline(ctx, listID, maxProfilesNbr, func(listID string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+		return s.fetchListSubscribers(ctx, listID, maxProfilesNbr, cursor)
+	})
+}
+
+// FetchListSubscribers gets subscribers of a given list, via the Twitter frontend API.
+func (s *Scraper) FetchListSubscribers(listID string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+	return s.fetchListSubscribers(context.Background(), listID, maxProfilesNbr, cursor)
+}
+
+// fetchListSubscribers gets subscribers of a given list with the context
+func (s *Scraper) fetchListSubscribers(ctx context.Context, listID string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+	jsn, err := s.fetchListGraphQL(ctx, "74wGEkaBxrdoXakWTWMxRQ/ListSubscribers", listID, maxProfilesNbr, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	profiles, nextCursor := jsn.Data.List.SubscribersTimeline.Timeline.parseUsers()
+	return profiles, nextCursor, nil
+}
+
+// GetListTweets returns channel with latest tweets of a given list.
+func (s *Scraper) GetListTweets(ctx context.Context, listID string, maxTweetsNbr int) <-chan *TweetResult {
+	return getTweetTimeline(ctx, listID, maxTweetsNbr, func(listID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+		return s.fetchListTweets(ctx, listID, maxTweetsNbr, cursor)
+	})
+}
+
+// FetchListTweets gets latest tweets of a given list, via the Twitter frontend API.
+func (s *Scraper) FetchListTweets(listID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	return s.fetchListTweets(context.Background(), listID, maxTweetsNbr, cursor)
+}
+
+// fetchListTweets gets latest tweets of a given list with the context
+func (s *Scraper) fetchListTweets(ctx context.Context, listID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	jsn, err := s.fetchListGraphQL(ctx, "2TemLyqrMpTeAmysdbnVqw/ListLatestTweetsTimeline", listID, maxTweetsNbr, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	tweets, nextCursor := jsn.Data.List.TweetsTimeline.Timeline.parseTweets()
+	return s.prepareTweets(tweets), nextCursor, nil
+}
+
+// GetUserLists returns channel with lists owned by a given user.
+func (s *Scraper) GetUserLists(ctx context.Context, user string, maxListsNbr int) <-chan *ListResult {
+	return getListTimeline(ctx, user, maxListsNbr, func(user string, maxListsNbr int, cursor string) ([]*List, string, error) {
+		return s.fetchUserLists(ctx, "6Ff9ZhaaMr8S4ZoJE-bFRw/ListOwnerships", user, maxListsNbr, cursor)
+	})
+}
+
+// FetchUserLists gets lists owned by a given user, via the Twitter frontend API.
+func (s *Scraper) FetchUserLists(user string, maxListsNbr int, cursor string) ([]*List, string, error) {
+	return s.fetchUserLists(context.Background(), "6Ff9ZhaaMr8S4ZoJE-bFRw/ListOwnerships", user, maxListsNbr, cursor)
+}
+
+// GetUserListMemberships returns channel with lists containing a given user.
+func (s *Scraper) GetUserListMemberships(ctx context.Context, user string, maxListsNbr int) <-chan *ListResult {
+	return getListTimeline(ctx, user, maxListsNbr, func(user string, maxListsNbr int, cursor string) ([]*List, string, error) {
+		return s.fetchUserLists(ctx, "BlEXXdARdSeL_0KyKHHvvg/ListMemberships", user, maxListsNbr, cursor)
+	})
+}
+
+// FetchUserListMemberships gets lists containing a given user, via the Twitter frontend API.
+func (s *Scraper) FetchUserListMemberships(user string, maxListsNbr int, cursor string) ([]*List, string, error) {
+	return s.fetchUserLists(context.Background(), "BlEXXdARdSeL_0KyKHHvvg/ListMemberships", user, maxListsNbr, cursor)
+}
+
+// fetchListGraphQL gets GraphQL list operation for a given list
+func (s *Scraper) fetchListGraphQL(ctx context.Context, operation string, listID string, maxNbr int, cursor string) (*listTimeline, error) {
+	variables := map[string]interface{}{
+		"listId": listID,
+	}
+	if maxNbr > 0 {
+		if maxNbr > 50 {
+			maxNbr = 50
+		}
+		variables["count"] = maxNbr
+	}
+	if cursor != "" {
+		variables["cursor"] = cursor
+	}
+
+	req, err := s.newGraphQLRequest(operation, variables)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	var jsn listTimeline
+	err = s.RequestAPI(req, &jsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(jsn.Errors) > 0 && jsn.Data.List.IDStr == "" &&
+		len(jsn.Data.List.MembersTimeline.Timeline.Instructions) == 0 &&
+		len(jsn.Data.List.SubscribersTimeline.Timeline.Instructions) == 0 &&
+		len(jsn.Data.List.TweetsTimeline.Timeline.Instructions) == 0 {
+		return nil, jsn.Errors[0]
+	}
+	return &jsn, nil
+}
+
+// fetchUserLists gets lists of GraphQL user list operation for a given user
+func (s *Scraper) fetchUserLists(ctx context.Context, operation string, user string, maxListsNbr int, cursor string) ([]*List, string, error) {
+	if maxListsNbr > 50 {
+		maxListsNbr = 50
+	}
+
+	userID, err := s.GetUserIDByScreenName(user)
+	if err != nil {
+		return nil, "", err
+	}
+
+	variables := map[string]interface{}{
+		"userId":                   userID,
+		"count":                    maxListsNbr,
+		"isListMemberTargetUserId": userID,
+	}
+	if cursor != "" {
+		variables["cursor"] = cursor
+	}
+
+	req, err := s.newGraphQLRequest(operation, variables)
+	if err != nil {
+		return nil, "", err
+	}
+	req = req.WithContext(ctx)
+
+	var jsn userTimeline
+	err = s.RequestAPI(req, &jsn)
+	if err != nil {
+		return nil, "", err
+	}
+
+	timeline := &jsn.Data.User.Result.Timeline.Timeline
+	if len(jsn.Errors) > 0 && len(timeline.Instructions) == 0 {
+		return nil, "", jsn.Errors[0]
+	}
+
+	lists, nextCursor := timeline.parseLists()
+	return lists, nextCursor, nil
+}
+
+func parseList(list listResult) List {
+	l := List{
+		ID:              list.IDStr,
+		Name:            list.Name,
+		Description:     list.Description,
+		Banner:          list.CustomBannerMedia.MediaInfo.OriginalImgURL,
+		IsPrivate:       list.Mode == "Private",
+		MemberCount:     list.MemberCount,
+		SubscriberCount: list.SubscriberCount,
+		URL:             "https://twitter.com/i/lists/" + list.IDStr,
+	}
+
+	if l.Banner == "" {
+		l.Banner = list.DefaultBannerMedia.MediaInfo.OriginalImgURL
+	}
+
+	if list.CreatedAt > 0 {
+		tm := time.Unix(0, list.CreatedAt*int64(time.Millisecond)).UTC()
+		l.Created = &tm
+	}
+
+	if list.UserResults.Result.RestId != "" {
+		l.Owner = parseProfile(list.UserResults.Result)
+	}
+
+	return l
+}
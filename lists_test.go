@@ -0,0 +1,141 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/n0madic/twitter-scraper"
+)
+
+func TestGetListFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	list, err := scraper.GetList(context.Background(), "1712800000000000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.ID != "1712800000000000001" || list.Name != "Official accounts" {
+		t.Errorf("Unexpected list %s %s", list.ID, list.Name)
+	}
+	if list.Description != "Accounts run by Official accounts" {
+		t.Errorf("Unexpected list Description %s", list.Description)
+	}
+	if list.MemberCount != 12 || list.SubscriberCount != 3400 {
+		t.Errorf("Unexpected list counts %d/%d", list.MemberCount, list.SubscriberCount)
+	}
+	if list.IsPrivate {
+		t.Error("Expected list IsPrivate is false")
+	}
+	if list.Owner.Username != "Twitter" {
+		t.Errorf("Expected list owner Twitter, got %s", list.Owner.Username)
+	}
+	if list.Created == nil || !list.Created.Equal(time.Unix(1690000000, 0)) {
+		t.Errorf("Unexpected list Created %v", list.Created)
+	}
+	if list.Banner != "https://pbs.twimg.com/list_banner_img/1712800000000000001/banner" {
+		t.Errorf("Unexpected list Banner %s", list.Banner)
+	}
+	if list.URL != "https://twitter.com/i/lists/1712800000000000001" {
+		t.Errorf("Unexpected list URL %s", list.URL)
+	}
+}
+
+func TestGetListMembersFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	var members []string
+	for profile := range scraper.GetListMembers(context.Background(), "1712800000000000001", 10) {
+		if profile.Error != nil {
+			t.Fatal(profile.Error)
+		}
+		members = append(members, profile.Username)
+	}
+	if len(members) != 2 || members[0] != "TwitterDev" || members[1] != "Support" {
+		t.Errorf("Unexpected list members %v", members)
+	}
+
+	var subscribers []string
+	for profile := range scraper.GetListSubscribers(context.Background(), "1712800000000000001", 10) {
+		if profile.Error != nil {
+			t.Fatal(profile.Error)
+		}
+		subscribers = append(subscribers, profile.Username)
+	}
+	if len(subscribers) != 1 || subscribers[0] != "Twitter" {
+		t.Errorf("Unexpected list subscribers %v", subscribers)
+	}
+}
+
+func TestGetListTweetsFixture(t *testing.T) {
+	scraper, transport := twitterscraper.NewFixtureScraper()
+	count := 0
+	for tweet := range scraper.GetListTweets(context.Background(), "1712800000000000001", 10) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		count++
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 list tweets, got %d", count)
+	}
+	if listID := twitterscraper.FixtureVariables(transport.Requests()[0])["listId"]; listID != "1712800000000000001" {
+		t.Errorf("Expected listId 1712800000000000001, got %v", listID)
+	}
+}
+
+func TestGetUserListsFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	var owned []twitterscraper.List
+	for list := range scraper.GetUserLists(context.Background(), "Twitter", 10) {
+		if list.Error != nil {
+			t.Fatal(list.Error)
+		}
+		owned = append(owned, list.List)
+	}
+	if len(owned) != 2 {
+		t.Fatalf("Expected 2 owned lists, got %d", len(owned))
+	}
+	if !owned[1].IsPrivate {
+		t.Error("Expected second list IsPrivate is true")
+	}
+	if owned[1].Banner != "https://pbs.twimg.com/media/default_banner.png" {
+		t.Errorf("Expected default banner, got %s", owned[1].Banner)
+	}
+
+	var memberships []twitterscraper.List
+	for list := range scraper.GetUserListMemberships(context.Background(), "Twitter", 10) {
+		if list.Error != nil {
+			t.Fatal(list.Error)
+		}
+		memberships = append(memberships, list.List)
+	}
+	if len(memberships) != 1 || memberships[0].Owner.Username != "TwitterDev" {
+		t.Errorf("Unexpected list memberships %v", memberships)
+	}
+}
+
+type listContextKey struct{}
+
+func TestListContextFixture(t *testing.T) {
+	scraper, transport := twitterscraper.NewFixtureScraper()
+	ctx := context.WithValue(context.Background(), listContextKey{}, "lists")
+	for range scraper.GetListMembers(ctx, "1712800000000000001", 10) {
+	}
+	for range scraper.GetListSubscribers(ctx, "1712800000000000001", 10) {
+	}
+	for range scraper.GetListTweets(ctx, "1712800000000000001", 10) {
+	}
+	for range scraper.GetUserLists(ctx, "Twitter", 10) {
+	}
+	for range scraper.GetUserListMemberships(ctx, "Twitter", 10) {
+	}
+
+	for _, req := range transport.Requests() {
+		if strings.HasSuffix(req.URL.Path, "/UserByScreenName") {
+			continue
+		}
+		if req.Context().Value(listContextKey{}) != "lists" {
+			t.Errorf("Expected request %s with the context of the caller", req.URL.Path)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package twitterscraper
+
+import (
+	"fmt"
+	"time"
+)
+
+type (
+	// Photo attached to the tweet.
+	Photo struct {
+		ID               string
+		MediaKey         string
+		URL              string
+		Width            int
+		Height           int
+		AltText          string
+		Color            string
+		SensitiveWarning SensitiveMediaWarning
+	}
+
+	// Video attached to the tweet.
+	// URL is the best variant, HLSURL is the adaptive streaming playlist if available.
+	Video struct {
+		ID               string
+		MediaKey         string
+		Preview          string
+		URL              string
+		HLSURL           string
+		Width            int
+		Height           int
+		AspectRatio      [2]int
+		Duration         time.Duration
+		AltText          string
+		Color            string
+		SensitiveWarning SensitiveMediaWarning
+		Views            int
+		Variants         []VideoVariant
+	}
+
+	// AnimatedGIF attached to the tweet, it is served as looped video without sound.
+	AnimatedGIF struct {
+		Video
+	}
+
+	// VideoVariant is a rendition of the video.
+	VideoVariant struct {
+		Bitrate     int
+		ContentType string
+		URL         string
+	}
+
+	// SensitiveMediaWarning flags of the media.
+	SensitiveMediaWarning struct {
+		AdultContent    bool `json:"adult_content"`
+		GraphicViolence bool `json:"graphic_violence"`
+		Other           bool `json:"other"`
+	}
+)
+
+// IsSensitive reports whether any of warning flags is set.
+func (w SensitiveMediaWarning) IsSensitive() bool {
+	return w.AdultContent || w.GraphicViolence || w.Other
+}
+
+// BestVariant returns MP4 variant with the highest bitrate.
+// If there is no MP4 variant, the first one is returned, like HLS playlist.
+func (v Video) BestVariant() VideoVariant {
+	var best VideoVariant
+	found := false
+	for _, variant := range v.Variants {
+		if variant.ContentType != "video/mp4" {
+			continue
+		}
+		if !found || variant.Bitrate > best.Bitrate {
+			best = variant
+			found = true
+		}
+	}
+	if !found && len(v.Variants) > 0 {
+		return v.Variants[0]
+	}
+	return best
+}
+
+// parseMedia splits raw media of the tweet into photos, videos and animated GIFs,
+// media with a sensitive warning marks the whole tweet as sensitive
+func parseMedia(tweet *Tweet, media []Media) {
+	for _, m := range media {
+		if m.ExtSensitiveMediaWarning.IsSensitive() {
+			tweet.SensitiveContent = true
+		}
+		switch m.Type {
+		case "photo":
+			tweet.Photos = append(tweet.Photos, parsePhoto(m))
+		case "video":
+			tweet.Videos = append(tweet.Videos, parseVideo(m))
+		case "animated_gif":
+			tweet.GIFs = append(tweet.GIFs, AnimatedGIF{parseVideo(m)})
+		}
+	}
+}
+
+func parsePhoto(m Media) Photo {
+	return Photo{
+		ID:               m.IDStr,
+		MediaKey:         m.MediaKey,
+		URL:              m.MediaURLHttps,
+		Width:            m.OriginalInfo.Width,
+		Height:           m.OriginalInfo.Height,
+		AltText:          m.ExtAltText,
+		Color:            dominantColor(m),
+		SensitiveWarning: m.ExtSensitiveMediaWarning,
+	}
+}
+
+func parseVideo(m Media) Video {
+	video := Video{
+		ID:               m.IDStr,
+		MediaKey:         m.MediaKey,
+		Preview:          m.MediaURLHttps,
+		Width:            m.OriginalInfo.Width,
+		Height:           m.OriginalInfo.Height,
+		Duration:         time.Duration(m.VideoInfo.DurationMillis) * time.Millisecond,
+		AltText:          m.ExtAltText,
+		Color:            dominantColor(m),
+		SensitiveWarning: m.ExtSensitiveMediaWarning,
+		Views:            m.MediaStats.ViewCount,
+	}
+	if len(m.VideoInfo.AspectRatio) == 2 {
+		video.AspectRatio = [2]int{m.VideoInfo.AspectRatio[0], m.VideoInfo.AspectRatio[1]}
+	}
+	for _, variant := range m.VideoInfo.Variants {
+		video.Variants = append(video.Variants, VideoVariant{
+			Bitrate:     variant.Bitrate,
+			ContentType: variant.ContentType,
+			URL:         variant.URL,
+		})
+		if variant.ContentType == "application/x-mpegURL" {
+			video.HLSURL = variant.URL
+		}
+	}
+	video.URL = video.BestVariant().URL
+	return video
+}
+
+// dominantColor returns hex color with the largest percentage of the media palette
+func dominantColor(m Media) string {
+	var color string
+	var percentage float64
+	for _, c := range m.ExtMediaColor.Palette {
+		if color == "" || c.Percentage > percentage {
+			color = fmt.Sprintf("#%02x%02x%02x", c.Rgb.Red, c.Rgb.Green, c.Rgb.Blue)
+			percentage = c.Percentage
+		}
+	}
+	return color
+}
@@ -0,0 +1,74 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/n0madic/twitter-scraper"
+)
+
+func TestTweetMediaFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	var tweets []twitterscraper.Tweet
+	for tweet := range scraper.GetMediaTweets(context.Background(), "Twitter", 10) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		tweets = append(tweets, tweet.Tweet)
+	}
+	if len(tweets) != 3 {
+		t.Fatalf("Expected 3 tweets, got %d", len(tweets))
+	}
+
+	if len(tweets[0].Photos) != 1 || len(tweets[0].Videos) != 0 || len(tweets[0].GIFs) != 0 {
+		t.Fatalf("Expected 1 photo, got %d photos, %d videos, %d GIFs", len(tweets[0].Photos), len(tweets[0].Videos), len(tweets[0].GIFs))
+	}
+	photo := tweets[0].Photos[0]
+	if photo.Width != 2048 || photo.Height != 1536 || photo.AltText != "A photo" || photo.Color != "#0a141e" {
+		t.Errorf("Unexpected photo %+v", photo)
+	}
+
+	if len(tweets[1].Videos) != 1 {
+		t.Fatalf("Expected 1 video, got %d", len(tweets[1].Videos))
+	}
+	video := tweets[1].Videos[0]
+	if video.Duration != 15*time.Second || video.AspectRatio != [2]int{16, 9} || video.Views != 4242 {
+		t.Errorf("Unexpected video %v %v %d", video.Duration, video.AspectRatio, video.Views)
+	}
+	if !video.SensitiveWarning.GraphicViolence || !video.SensitiveWarning.IsSensitive() {
+		t.Errorf("Expected video sensitive warning, got %+v", video.SensitiveWarning)
+	}
+	if tweets[0].SensitiveContent || !tweets[1].SensitiveContent {
+		t.Errorf("Expected only the tweet with video to be sensitive, got %v %v", tweets[0].SensitiveContent, tweets[1].SensitiveContent)
+	}
+	if len(video.Variants) != 4 || video.Variants[0].ContentType != "application/x-mpegURL" {
+		t.Errorf("Unexpected video variants %v", video.Variants)
+	}
+	if best := video.BestVariant(); best.Bitrate != 2176000 || video.URL != best.URL {
+		t.Errorf("Unexpected best variant %v, URL %s", best, video.URL)
+	}
+	if video.HLSURL != video.Variants[0].URL {
+		t.Errorf("Unexpected HLSURL %s", video.HLSURL)
+	}
+
+	if len(tweets[2].GIFs) != 1 || len(tweets[2].Videos) != 0 {
+		t.Fatalf("Expected 1 GIF, got %d GIFs, %d videos", len(tweets[2].GIFs), len(tweets[2].Videos))
+	}
+	if gif := tweets[2].GIFs[0]; gif.URL == "" || gif.AspectRatio != [2]int{1, 1} || gif.BestVariant().ContentType != "video/mp4" {
+		t.Errorf("Unexpected GIF %+v", gif)
+	}
+}
+
+func TestVideoBestVariant(t *testing.T) {
+	video := twitterscraper.Video{Variants: []twitterscraper.VideoVariant{
+		{ContentType: "application/x-mpegURL", URL: "playlist.m3u8"},
+	}}
+	if best := video.BestVariant(); best.URL != "playlist.m3u8" {
+		t.Errorf("Expected HLS playlist without MP4 variants, got %v", best)
+	}
+	video.Variants = nil
+	if best := video.BestVariant(); best.URL != "" {
+		t.Errorf("Expected empty variant, got %v", best)
+	}
+}
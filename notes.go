@@ -0,0 +1,140 @@
+package twitterscraper
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// CommunityNote is a note added to the tweet by Community Notes (Birdwatch) contributors.
+// RatingStatus is one of CurrentlyRatedHelpful, CurrentlyRatedNotHelpful or NeedsMoreRatings.
+type CommunityNote struct {
+	ID             string
+	Text           string
+	URLs           []string
+	Classification string
+	RatingStatus   string
+	Created        *time.Time
+	URL            string
+}
+
+type birdwatchText struct {
+	Text     string `json:"text"`
+	Entities []struct {
+		FromIndex int `json:"fromIndex"`
+		ToIndex   int `json:"toIndex"`
+		Ref       struct {
+			Type    string `json:"type"`
+			URL     string `json:"url"`
+			URLType string `json:"urlType"`
+		} `json:"ref"`
+	} `json:"entities"`
+}
+
+// birdwatchPivot is the note shown below the tweet
+type birdwatchPivot struct {
+	Note struct {
+		RestId       string      `json:"rest_id"`
+		RatingStatus string      `json:"rating_status"`
+		CreatedAt    json.Number `json:"created_at"`
+	} `json:"note"`
+	Subtitle       birdwatchText `json:"subtitle"`
+	DestinationURL string        `json:"destinationUrl"`
+}
+
+type birdwatchNote struct {
+	RestId string `json:"rest_id"`
+	DataV1 struct {
+		Classification string        `json:"classification"`
+		Summary        birdwatchText `json:"summary"`
+	} `json:"data_v1"`
+	RatingStatus string      `json:"rating_status"`
+	CreatedAt    json.Number `json:"created_at"`
+}
+
+// birdwatchNotes JSON object returned by BirdwatchFetchNotes
+type birdwatchNotes struct {
+	Errors []Err `json:"errors"`
+	Data   struct {
+		TweetResultByRestId struct {
+			Result struct {
+				MisleadingBirdwatchNotes struct {
+					Notes []birdwatchNote `json:"notes"`
+				} `json:"misleading_birdwatch_notes"`
+				NotMisleadingBirdwatchNotes struct {
+					Notes []birdwatchNote `json:"notes"`
+				} `json:"not_misleading_birdwatch_notes"`
+			} `json:"result"`
+		} `json:"tweet_result_by_rest_id"`
+	} `json:"data"`
+}
+
+// GetCommunityNotes returns all notes proposed for the tweet, including notes which are not shown.
+// Community Notes are visible only with authenticated session, see WithCookie.
+func (s *Scraper) GetCommunityNotes(ctx context.Context, tweetID string) ([]CommunityNote, error) {
+	if !s.IsLoggedIn() {
+		return nil, ErrAuthRequired
+	}
+
+	variables := map[string]interface{}{
+		"tweet_id": tweetID,
+	}
+
+	req, err := s.newGraphQLRequest("LTRL7fzOa2k0hNMCyu7hYw/BirdwatchFetchNotes", variables)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	var jsn birdwatchNotes
+	err = s.RequestAPI(req, &jsn)
+	if err != nil {
+		return nil, err
+	}
+
+	result := jsn.Data.TweetResultByRestId.Result
+	notes := append(result.MisleadingBirdwatchNotes.Notes, result.NotMisleadingBirdwatchNotes.Notes...)
+	if len(jsn.Errors) > 0 && len(notes) == 0 {
+		return nil, jsn.Errors[0]
+	}
+
+	var communityNotes []CommunityNote
+	for _, note := range notes {
+		communityNotes = append(communityNotes, CommunityNote{
+			ID:             note.RestId,
+			Text:           note.DataV1.Summary.Text,
+			URLs:           note.DataV1.Summary.urls(),
+			Classification: note.DataV1.Classification,
+			RatingStatus:   note.RatingStatus,
+			Created:        msecToTime(note.CreatedAt),
+			URL:            "https://twitter.com/i/birdwatch/n/" + note.RestId,
+		})
+	}
+	return communityNotes, nil
+}
+
+// urls returns links of the note text
+func (t birdwatchText) urls() []string {
+	var urls []string
+	for _, entity := range t.Entities {
+		if entity.Ref.Type == "TimelineUrl" && entity.Ref.URLType == "ExternalUrl" {
+			urls = append(urls, entity.Ref.URL)
+		}
+	}
+	return urls
+}
+
+// parseBirdwatchPivot returns note shown below the tweet or nil
+func parseBirdwatchPivot(pivot birdwatchPivot) *CommunityNote {
+	if pivot.Note.RestId == "" {
+		return nil
+	}
+	return &CommunityNote{
+		ID:           pivot.Note.RestId,
+		Text:         pivot.Subtitle.Text,
+		URLs:         pivot.Subtitle.urls(),
+		RatingStatus: pivot.Note.RatingStatus,
+		Created:      msecToTime(pivot.Note.CreatedAt),
+		URL:          "https://twitter.com/i/birdwatch/n/" + pivot.Note.RestId,
+	}
+}
@@ -0,0 +1,76 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	twitterscraper "github.com/n0madic/twitter-scraper"
+)
+
+func TestTweetCommunityNoteFixture(t *testing.T) {
+	scraper, transport := twitterscraper.NewFixtureScraper()
+	tweets, users, err := scraper.GetTweetAndRepliesRecursive("1713100000000000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedIDs := []string{"1713100000000000001", "1713100000000000002", "1713100000000000003"}
+	if len(tweets) != len(expectedIDs) {
+		t.Fatalf("Expected %d tweets, got %d", len(expectedIDs), len(tweets))
+	}
+	for i, id := range expectedIDs {
+		if tweets[i].ID != id {
+			t.Errorf("Expected tweet #%d ID %s, got %s", i, id, tweets[i].ID)
+		}
+	}
+	if len(users) != 3 {
+		t.Errorf("Expected 3 users, got %d", len(users))
+	}
+
+	note := tweets[0].CommunityNote
+	if note == nil {
+		t.Fatal("Expected CommunityNote of the tweet")
+	}
+	if note.ID != "1713100000000000900" || note.RatingStatus != "CurrentlyRatedHelpful" {
+		t.Errorf("Unexpected CommunityNote %s %s", note.ID, note.RatingStatus)
+	}
+	if note.Created == nil || note.Created.Unix() != 1697033000 {
+		t.Errorf("Unexpected CommunityNote created %v", note.Created)
+	}
+	if len(note.URLs) != 1 || note.URLs[0] != "https://t.co/note0001" {
+		t.Errorf("Unexpected CommunityNote URLs %v", note.URLs)
+	}
+	if tweets[1].CommunityNote != nil {
+		t.Errorf("Unexpected CommunityNote of the reply %v", tweets[1].CommunityNote)
+	}
+
+	if notes := twitterscraper.FixtureVariables(transport.Requests()[0])["withBirdwatchNotes"]; notes != true {
+		t.Errorf("Expected withBirdwatchNotes true, got %v", notes)
+	}
+}
+
+func TestGetCommunityNotesFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	_, err := scraper.GetCommunityNotes(context.Background(), "1713100000000000001")
+	if !errors.Is(err, twitterscraper.ErrAuthRequired) {
+		t.Errorf("Expected ErrAuthRequired, got %v", err)
+	}
+
+	scraper.WithCookie("auth_token=fixture; ct0=fixture").WithXCsrfToken("fixture")
+	notes, err := scraper.GetCommunityNotes(context.Background(), "1713100000000000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notes) != 2 {
+		t.Fatalf("Expected 2 notes, got %d", len(notes))
+	}
+	if notes[0].Classification != "MisinformedOrPotentiallyMisleading" || notes[0].RatingStatus != "CurrentlyRatedHelpful" {
+		t.Errorf("Unexpected note %s %s", notes[0].Classification, notes[0].RatingStatus)
+	}
+	if notes[1].RatingStatus != "NeedsMoreRatings" || notes[1].Created == nil || notes[1].Created.Unix() != 1697034000 {
+		t.Errorf("Unexpected note %s %v", notes[1].RatingStatus, notes[1].Created)
+	}
+	if notes[1].URL != "https://twitter.com/i/birdwatch/n/1713100000000000901" {
+		t.Errorf("Unexpected note URL %s", notes[1].URL)
+	}
+}
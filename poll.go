@@ -0,0 +1,90 @@
+package twitterscraper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Poll attached to the tweet.
+type Poll struct {
+	Options    []PollOption
+	TotalVotes int
+	EndTime    time.Time
+	Duration   time.Duration
+	Updated    time.Time
+	IsFinal    bool
+}
+
+// PollOption of the poll.
+type PollOption struct {
+	Label      string
+	Votes      int
+	Percentage float64
+}
+
+// Winner returns option with the most votes, nil if there are no votes or it is a tie.
+func (p *Poll) Winner() *PollOption {
+	var winner *PollOption
+	tie := false
+	for i := range p.Options {
+		option := &p.Options[i]
+		switch {
+		case winner == nil || option.Votes > winner.Votes:
+			winner = option
+			tie = false
+		case option.Votes == winner.Votes:
+			tie = true
+		}
+	}
+	if winner == nil || winner.Votes == 0 || tie {
+		return nil
+	}
+	return winner
+}
+
+// parsePoll returns poll of the card, nil if card is not a poll.
+// Poll cards are named like poll2choice_text_only or poll4choice_image.
+func parsePoll(card Card) *Poll {
+	name := card.Legacy.Name
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		name = name[i+1:]
+	}
+	if !strings.HasPrefix(name, "poll") || !strings.Contains(name, "choice") {
+		return nil
+	}
+
+	poll := &Poll{}
+	for i := 1; i <= 4; i++ {
+		label := card.bindingValue(fmt.Sprintf("choice%d_label", i))
+		if label == "" {
+			break
+		}
+		votes, _ := strconv.Atoi(card.bindingValue(fmt.Sprintf("choice%d_count", i)))
+		poll.Options = append(poll.Options, PollOption{Label: label, Votes: votes})
+		poll.TotalVotes += votes
+	}
+	if len(poll.Options) == 0 {
+		return nil
+	}
+
+	for i := range poll.Options {
+		if poll.TotalVotes > 0 {
+			poll.Options[i].Percentage = float64(poll.Options[i].Votes) * 100 / float64(poll.TotalVotes)
+		}
+	}
+
+	poll.EndTime, _ = time.Parse(time.RFC3339, card.bindingValue("end_datetime_utc"))
+	poll.Updated, _ = time.Parse(time.RFC3339, card.bindingValue("last_updated_datetime_utc"))
+	if minutes, err := strconv.Atoi(card.bindingValue("duration_minutes")); err == nil {
+		poll.Duration = time.Duration(minutes) * time.Minute
+	}
+	for _, v := range card.Legacy.BindingValues {
+		if v.Key == "counts_are_final" {
+			poll.IsFinal = v.Value.BooleanValue
+		}
+	}
+
+	return poll
+}
@@ -0,0 +1,52 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/n0madic/twitter-scraper"
+)
+
+func TestTweetPollFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	polls := map[string]*twitterscraper.Poll{}
+	for tweet := range scraper.GetTweets(context.Background(), "Twitter", 10) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		polls[tweet.ID] = tweet.Poll
+	}
+
+	if polls["1712200000000000001"] != nil {
+		t.Errorf("Expected no poll of the tweet with space card, got %v", polls["1712200000000000001"])
+	}
+
+	poll := polls["1712100000000000002"]
+	if poll == nil {
+		t.Fatal("Expected poll of the tweet")
+	}
+	if len(poll.Options) != 2 || poll.Options[0].Label != "Yes" || poll.Options[1].Votes != 100 {
+		t.Errorf("Unexpected poll options %v", poll.Options)
+	}
+	if poll.TotalVotes != 400 || poll.Options[0].Percentage != 75 {
+		t.Errorf("Unexpected poll votes %d %v", poll.TotalVotes, poll.Options[0].Percentage)
+	}
+	if !poll.IsFinal || poll.Duration != 24*time.Hour || poll.EndTime.Unix() != 1697108400 {
+		t.Errorf("Unexpected poll %v %v %v", poll.IsFinal, poll.Duration, poll.EndTime)
+	}
+	if winner := poll.Winner(); winner == nil || winner.Label != "Yes" {
+		t.Errorf("Expected poll winner Yes, got %v", winner)
+	}
+
+	poll = polls["1712100000000000003"]
+	if poll == nil {
+		t.Fatal("Expected poll of the tweet with image")
+	}
+	if len(poll.Options) != 3 || poll.IsFinal || poll.Duration != 7*24*time.Hour {
+		t.Errorf("Unexpected poll %v %v %v", poll.Options, poll.IsFinal, poll.Duration)
+	}
+	if winner := poll.Winner(); winner != nil {
+		t.Errorf("Expected no winner of the tie, got %v", winner)
+	}
+}
@@ -3,13 +3,9 @@ package twitterscraper
 import (
 	"fmt"
 	"net/http"
-	"sync"
 	"time"
 )
 
-// Global cache for user IDs
-var cacheIDs sync.Map
-
 // Profile of twitter user.
 type Profile struct {
 	Avatar           string
@@ -92,7 +88,7 @@ func (s *Scraper) GetProfile(username string) (Profile, error) {
 		return Profile{}, fmt.Errorf("either @%s does not exist or is private", username)
 	}
 
-	return parseProfile(jsn), nil
+	return parseProfile(jsn.Data.User.Result), nil
 }
 
 // Deprecated: GetProfile wrapper for default scraper
@@ -102,7 +98,7 @@ func GetProfile(username string) (Profile, error) {
 
 // GetUserIDByScreenName from API
 func (s *Scraper) GetUserIDByScreenName(screenName string) (string, error) {
-	id, ok := cacheIDs.Load(screenName)
+	id, ok := s.cacheIDs.Load(screenName)
 	if ok {
 		return id.(string), nil
 	}
@@ -112,7 +108,7 @@ func (s *Scraper) GetUserIDByScreenName(screenName string) (string, error) {
 		return "", err
 	}
 
-	cacheIDs.Store(screenName, profile.UserID)
+	s.cacheIDs.Store(screenName, profile.UserID)
 
 	return profile.UserID, nil
 }
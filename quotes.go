@@ -0,0 +1,39 @@
+package twitterscraper
+
+// parseQuotedStatus returns the quoted tweet of the result, a tombstone if the quoted tweet
+// is deleted or unavailable, and nil if the response doesn't include it
+func parseQuotedStatus(result tweetResult) *Tweet {
+	quoted := result.QuotedStatusResult.Result
+	if quoted == nil {
+		return nil
+	}
+
+	switch quoted.TypeName {
+	case "TweetTombstone", "TweetUnavailable":
+		return &Tweet{
+			ID:          result.Legacy.QuotedStatusIDStr,
+			Text:        quoted.Tombstone.Text.Text,
+			IsTombstone: true,
+		}
+	}
+
+	tweet, err := parseTweetResult(*quoted)
+	if err != nil {
+		return &Tweet{ID: result.Legacy.QuotedStatusIDStr, IsTombstone: true}
+	}
+	return &tweet
+}
+
+// limitQuoteDepth drops quoted tweets nested deeper than depth
+func limitQuoteDepth(tweet *Tweet, depth int) {
+	if tweet.RetweetedStatus != nil {
+		limitQuoteDepth(tweet.RetweetedStatus, depth)
+	}
+	for level := 1; tweet.QuotedStatus != nil; level++ {
+		if level > depth {
+			tweet.QuotedStatus = nil
+			return
+		}
+		tweet = tweet.QuotedStatus
+	}
+}
@@ -0,0 +1,97 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	twitterscraper "github.com/n0madic/twitter-scraper"
+)
+
+func TestGetTweetQuotesFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	tweet, err := scraper.GetTweet("1713100000000000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tweet.IsQuoted || tweet.QuoteRetweetId != "1713000000000000011" {
+		t.Errorf("Expected tweet is quote, got IsQuoted %v %s", tweet.IsQuoted, tweet.QuoteRetweetId)
+	}
+
+	quoted := tweet.QuotedStatus
+	if quoted == nil || quoted.ID != "1713000000000000011" || quoted.Username != "TwitterDev" || quoted.Text != "Quoting support" {
+		t.Fatalf("Unexpected quoted tweet %v", quoted)
+	}
+	quoted = quoted.QuotedStatus
+	if quoted == nil || quoted.ID != "1713000000000000012" || quoted.Username != "Support" || !quoted.IsQuoted {
+		t.Fatalf("Unexpected quoted tweet of the second level %v", quoted)
+	}
+	deleted := quoted.QuotedStatus
+	if deleted == nil || deleted.ID != "1713000000000000013" || !deleted.IsTombstone ||
+		deleted.Text != "This Post was deleted by the Post author. Learn more" {
+		t.Fatalf("Expected tombstone of the deleted tweet, got %v", deleted)
+	}
+
+	scraper.WithQuoteDepth(1)
+	tweet, err = scraper.GetTweet("1713100000000000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tweet.QuotedStatus == nil || tweet.QuotedStatus.QuotedStatus != nil {
+		t.Errorf("Expected only one level of quotes, got %v", tweet.QuotedStatus)
+	}
+	tweets, _, err := scraper.GetTweetAndRepliesRecursive("1713100000000000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tweets) == 0 || tweets[0].QuotedStatus == nil || tweets[0].QuotedStatus.QuotedStatus != nil {
+		t.Errorf("Expected only one level of quotes in the conversation, got %v", tweets)
+	}
+
+	reply, err := scraper.GetTweet("1713100000000000002")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reply.IsQuoted || reply.QuotedStatus == nil || reply.QuotedStatus.ID != "1713000000000000014" || !reply.QuotedStatus.IsTombstone {
+		t.Errorf("Expected tombstone of the unavailable tweet, got %v", reply.QuotedStatus)
+	}
+
+	_, err = scraper.GetTweet("1713100000000000999")
+	if !errors.Is(err, twitterscraper.ErrTweetNotFound) {
+		t.Errorf("Expected ErrTweetNotFound, got %v", err)
+	}
+}
+
+func TestLegacyQuoteFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	scraper.WithCookie("auth_token=fixture; ct0=fixture").WithXCsrfToken("fixture")
+	var tweets []twitterscraper.Tweet
+	for tweet := range scraper.GetMentions(context.Background(), 2) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		tweets = append(tweets, tweet.Tweet)
+	}
+	if len(tweets) != 2 {
+		t.Fatalf("Expected 2 mentions, got %d", len(tweets))
+	}
+	if tweets[0].IsQuoted || tweets[0].QuotedStatus != nil {
+		t.Error("Expected first mention is not quote")
+	}
+	quoted := tweets[1].QuotedStatus
+	if !tweets[1].IsQuoted || quoted == nil || quoted.ID != tweets[0].ID || quoted.Username != "TwitterDev" {
+		t.Errorf("Unexpected quoted tweet %v", quoted)
+	}
+
+	quotes, _, err := scraper.FetchMentions(20, "quotes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quotes) != 1 {
+		t.Fatalf("Expected 1 mention, got %d", len(quotes))
+	}
+	deleted := quotes[0].QuotedStatus
+	if !quotes[0].IsQuoted || deleted == nil || deleted.ID != "1713100000000000099" || !deleted.IsTombstone {
+		t.Errorf("Expected tombstone of the missing quoted tweet, got %v", deleted)
+	}
+}
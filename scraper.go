@@ -21,9 +21,14 @@ type Scraper struct {
 	guestToken     string
 	guestCreatedAt time.Time
 	includeReplies bool
+	unwrapRetweets bool
+	quoteDepth     int
 	searchMode     SearchMode
 	wg             sync.WaitGroup
 
+	// cache of user IDs by screen name
+	cacheIDs sync.Map
+
 	cookie     string
 	xCsrfToken string
 }
@@ -67,6 +72,11 @@ func (s *Scraper) IsGuestToken() bool {
 	return s.guestToken != ""
 }
 
+// IsLoggedIn check if cookie and x csrf token are set
+func (s *Scraper) IsLoggedIn() bool {
+	return len(s.cookie) > 0 && len(s.xCsrfToken) > 0
+}
+
 // SetSearchMode switcher
 func (s *Scraper) SetSearchMode(mode SearchMode) *Scraper {
 	s.searchMode = mode
@@ -100,6 +110,18 @@ func WithReplies(b bool) *Scraper {
 	return defaultScraper.WithReplies(b)
 }
 
+// WithUnwrapRetweets enable/disable replacing retweets on timelines by the original tweets
+func (s *Scraper) WithUnwrapRetweets(b bool) *Scraper {
+	s.unwrapRetweets = b
+	return s
+}
+
+// WithQuoteDepth limits nesting of quoted tweets, 1 keeps quoted tweets without their quotes, 0 is no limit
+func (s *Scraper) WithQuoteDepth(depth int) *Scraper {
+	s.quoteDepth = depth
+	return s
+}
+
 // cookie
 func (s *Scraper) WithCookie(cookie string) *Scraper {
 	s.cookie = cookie
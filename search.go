@@ -1,87 +1,108 @@
 package twitterscraper
 
 import (
-	"strconv"
+	"context"
+	"fmt"
 )
 
-//// SearchTweets returns channel with tweets for a given search query
-//func (s *Scraper) SearchTweets(ctx context.Context, query string, maxTweetsNbr int) <-chan *TweetResult {
-//	return getTweetTimeline(ctx, query, maxTweetsNbr, s.FetchSearchTweets)
-//}
-
-//// Deprecated: SearchTweets wrapper for default Scraper
-//func SearchTweets(ctx context.Context, query string, maxTweetsNbr int) <-chan *TweetResult {
-//	return defaultScraper.SearchTweets(ctx, query, maxTweetsNbr)
-//}
+// searchTimeline JSON object returned by SearchTimeline
+type searchTimeline struct {
+	Errors []Err `json:"errors"`
+	Data   struct {
+		SearchByRawQuery struct {
+			SearchTimeline struct {
+				Timeline timelineV2 `json:"timeline"`
+			} `json:"search_timeline"`
+		} `json:"search_by_raw_query"`
+	} `json:"data"`
+}
 
-// // SearchProfiles returns channel with profiles for a given search query
-// func (s *Scraper) SearchProfiles(ctx context.Context, query string, maxProfilesNbr int) <-chan *ProfileResult {
-// 	return getUserTimeline(ctx, query, maxProfilesNbr, s.FetchSearchProfiles)
-// }
+// SearchTweets returns channel with tweets for a given search query
+func (s *Scraper) SearchTweets(ctx context.Context, query string, maxTweetsNbr int) <-chan *TweetResult {
+	return getTweetTimeline(ctx, query, maxTweetsNbr, s.FetchSearchTweets)
+}
 
-// // Deprecated: SearchProfiles wrapper for default Scraper
-// func SearchProfiles(ctx context.Context, query string, maxProfilesNbr int) <-chan *ProfileResult {
-// 	return defaultScraper.SearchProfiles(ctx, query, maxProfilesNbr)
-// }
+// Deprecated: SearchTweets wrapper for default Scraper
+func SearchTweets(ctx context.Context, query string, maxTweetsNbr int) <-chan *TweetResult {
+	return defaultScraper.SearchTweets(ctx, query, maxTweetsNbr)
+}
 
-// getSearchTimeline gets results for a given search query, via the Twitter frontend API
-func (s *Scraper) getSearchTimeline(query string, maxNbr int, cursor string) (*timeline, error) {
-	if maxNbr > 50 {
-		maxNbr = 50
-	}
+// SearchProfiles returns channel with profiles for a given search query
+func (s *Scraper) SearchProfiles(ctx context.Context, query string, maxProfilesNbr int) <-chan *ProfileResult {
+	return getUserTimeline(ctx, query, maxProfilesNbr, s.FetchSearchProfiles)
+}
 
-	req, err := s.newRequest("GET", "https://twitter.com/i/api/2/search/adaptive.json")
-	if err != nil {
-		return nil, err
-	}
+// Deprecated: SearchProfiles wrapper for default Scraper
+func SearchProfiles(ctx context.Context, query string, maxProfilesNbr int) <-chan *ProfileResult {
+	return defaultScraper.SearchProfiles(ctx, query, maxProfilesNbr)
+}
 
-	q := req.URL.Query()
-	q.Add("q", query)
-	q.Add("count", strconv.Itoa(maxNbr))
-	q.Add("query_source", "typed_query")
-	q.Add("pc", "1")
-	q.Add("spelling_corrections", "1")
-	if cursor != "" {
-		q.Add("cursor", cursor)
-	}
+// searchProduct returns SearchTimeline product for the search mode
+func (s *Scraper) searchProduct() string {
 	switch s.searchMode {
 	case SearchLatest:
-		q.Add("tweet_search_mode", "live")
+		return "Latest"
 	case SearchPhotos:
-		q.Add("result_filter", "image")
+		return "Photos"
 	case SearchVideos:
-		q.Add("result_filter", "video")
+		return "Videos"
 	case SearchUsers:
-		q.Add("result_filter", "user")
+		return "People"
+	}
+	return "Top"
+}
+
+// getSearchTimeline gets results for a given search query and product, via the Twitter frontend GraphQL API
+func (s *Scraper) getSearchTimeline(ctx context.Context, query string, product string, maxNbr int, cursor string) (*timelineV2, error) {
+	if maxNbr > 50 {
+		maxNbr = 50
 	}
 
-	req.URL.RawQuery = q.Encode()
+	variables := map[string]interface{}{
+		"rawQuery":    query,
+		"count":       maxNbr,
+		"querySource": "typed_query",
+		"product":     product,
+	}
+	if cursor != "" {
+		variables["cursor"] = cursor
+	}
+
+	req, err := s.newGraphQLRequest("nK1dw4oV3k4w5TdtcAdSww/SearchTimeline", variables)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
 
-	var timeline timeline
-	err = s.RequestAPI(req, &timeline)
+	var jsn searchTimeline
+	err = s.RequestAPI(req, &jsn)
 	if err != nil {
 		return nil, err
 	}
-	return &timeline, nil
+
+	timeline := &jsn.Data.SearchByRawQuery.SearchTimeline.Timeline
+	if len(jsn.Errors) > 0 && len(timeline.Instructions) == 0 {
+		return nil, fmt.Errorf("%s", jsn.Errors[0].Message)
+	}
+	return timeline, nil
 }
 
-//
-//// FetchSearchTweets gets tweets for a given search query, via the Twitter frontend API
-//func (s *Scraper) FetchSearchTweets(query string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
-//	timeline, err := s.getSearchTimeline(query, maxTweetsNbr, cursor)
-//	if err != nil {
-//		return nil, "", err
-//	}
-//	tweets, nextCursor := timeline.parseTweets()
-//	return tweets, nextCursor, nil
-//}
+// FetchSearchTweets gets tweets for a given search query, via the Twitter frontend API
+func (s *Scraper) FetchSearchTweets(query string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	timeline, err := s.getSearchTimeline(context.Background(), query, s.searchProduct(), maxTweetsNbr, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	tweets, nextCursor := timeline.parseTweets()
+	return s.prepareTweets(tweets), nextCursor, nil
+}
 
-// // FetchSearchProfiles gets users for a given search query, via the Twitter frontend API
-// func (s *Scraper) FetchSearchProfiles(query string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
-// 	timeline, err := s.getSearchTimeline(query, maxProfilesNbr, cursor)
-// 	if err != nil {
-// 		return nil, "", err
-// 	}
-// 	users, nextCursor := timeline.parseUsers()
-// 	return users, nextCursor, nil
-// }
+// FetchSearchProfiles gets users for a given search query, via the Twitter frontend API
+func (s *Scraper) FetchSearchProfiles(query string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+	timeline, err := s.getSearchTimeline(context.Background(), query, "People", maxProfilesNbr, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	users, nextCursor := timeline.parseUsers()
+	return users, nextCursor, nil
+}
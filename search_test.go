@@ -3,7 +3,9 @@ package twitterscraper_test
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	twitterscraper "github.com/n0madic/twitter-scraper"
 )
 
@@ -25,32 +27,33 @@ func TestFetchSearchCursor(t *testing.T) {
 	}
 }
 
-// func TestGetSearchProfiles(t *testing.T) {
-// 	count := 0
-// 	maxProfilesNbr := 150
-// 	dupcheck := make(map[string]bool)
-// 	scraper := twitterscraper.New().SetSearchMode(twitterscraper.SearchUsers)
-// 	for profile := range scraper.SearchProfiles(context.Background(), "Twitter", maxProfilesNbr) {
-// 		if profile.Error != nil {
-// 			t.Error(profile.Error)
-// 		} else {
-// 			count++
-// 			if profile.UserID == "" {
-// 				t.Error("Expected UserID is empty")
-// 			} else {
-// 				if dupcheck[profile.UserID] {
-// 					t.Errorf("Detect duplicated UserID: %s", profile.UserID)
-// 				} else {
-// 					dupcheck[profile.UserID] = true
-// 				}
-// 			}
-// 		}
-// 	}
-
-//		if count != maxProfilesNbr {
-//			t.Errorf("Expected profiles count=%v, got: %v", maxProfilesNbr, count)
-//		}
-//	}
+func TestGetSearchProfiles(t *testing.T) {
+	count := 0
+	maxProfilesNbr := 150
+	dupcheck := make(map[string]bool)
+	scraper := twitterscraper.New().SetSearchMode(twitterscraper.SearchUsers)
+	for profile := range scraper.SearchProfiles(context.Background(), "Twitter", maxProfilesNbr) {
+		if profile.Error != nil {
+			t.Error(profile.Error)
+		} else {
+			count++
+			if profile.UserID == "" {
+				t.Error("Expected UserID is empty")
+			} else {
+				if dupcheck[profile.UserID] {
+					t.Errorf("Detect duplicated UserID: %s", profile.UserID)
+				} else {
+					dupcheck[profile.UserID] = true
+				}
+			}
+		}
+	}
+
+	if count != maxProfilesNbr {
+		t.Errorf("Expected profiles count=%v, got: %v", maxProfilesNbr, count)
+	}
+}
+
 func TestGetSearchTweets(t *testing.T) {
 	count := 0
 	maxTweetsNbr := 150
@@ -86,3 +89,133 @@ func TestGetSearchTweets(t *testing.T) {
 		t.Errorf("Expected tweets count=%v, got: %v", maxTweetsNbr, count)
 	}
 }
+
+func TestSearchTweetsFixture(t *testing.T) {
+	scraper, transport := twitterscraper.NewFixtureScraper()
+	var tweets []twitterscraper.Tweet
+	for tweet := range scraper.SearchTweets(context.Background(), "twitter", 10) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		tweets = append(tweets, tweet.Tweet)
+	}
+
+	expectedIDs := []string{"1712130000000000001", "1712130000000000002", "1712130000000000003", "1712120000000000004"}
+	if len(tweets) != len(expectedIDs) {
+		t.Fatalf("Expected %d tweets, got %d", len(expectedIDs), len(tweets))
+	}
+	for i, id := range expectedIDs {
+		if tweets[i].ID != id {
+			t.Errorf("Expected tweet #%d ID %s, got %s", i, id, tweets[i].ID)
+		}
+	}
+
+	tweet := tweets[0]
+	if tweet.Username != "Twitter" || tweet.UserID != "783214" {
+		t.Errorf("Unexpected tweet author %s (%s)", tweet.Username, tweet.UserID)
+	}
+	if tweet.PermanentURL != "https://twitter.com/Twitter/status/1712130000000000001" {
+		t.Errorf("Unexpected tweet PermanentURL %s", tweet.PermanentURL)
+	}
+	if tweet.Timestamp != 1697032862 || tweet.TimeParsed.Unix() != tweet.Timestamp {
+		t.Errorf("Unexpected tweet Timestamp %d", tweet.Timestamp)
+	}
+	if tweet.IsReply {
+		t.Error("Expected tweet IsReply is false")
+	}
+	if !tweets[1].IsReply || tweets[1].ReplyingTo != "1712130000000000001" {
+		t.Error("Expected second tweet is a reply")
+	}
+
+	requests := transport.Requests()
+	if len(requests) != 3 {
+		t.Fatalf("Expected 3 requests, got %d", len(requests))
+	}
+	for i, cursor := range []interface{}{nil, "search2", "search3"} {
+		if got := twitterscraper.FixtureVariables(requests[i])["cursor"]; got != cursor {
+			t.Errorf("Expected request #%d cursor %v, got %v", i, cursor, got)
+		}
+	}
+}
+
+func TestSearchModeFixture(t *testing.T) {
+	modes := map[twitterscraper.SearchMode]string{
+		twitterscraper.SearchTop:    "Top",
+		twitterscraper.SearchLatest: "Latest",
+		twitterscraper.SearchPhotos: "Photos",
+		twitterscraper.SearchVideos: "Videos",
+	}
+	for mode, product := range modes {
+		scraper, transport := twitterscraper.NewFixtureScraper()
+		scraper.SetSearchMode(mode)
+		tweets, cursor, err := scraper.FetchSearchTweets("twitter", 20, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tweets) != 3 || cursor != "search2" {
+			t.Errorf("Expected 3 tweets and cursor search2, got %d and %s", len(tweets), cursor)
+		}
+		variables := twitterscraper.FixtureVariables(transport.Requests()[0])
+		if variables["product"] != product {
+			t.Errorf("Expected product %s, got %v", product, variables["product"])
+		}
+		if variables["rawQuery"] != "twitter" {
+			t.Errorf("Expected rawQuery twitter, got %v", variables["rawQuery"])
+		}
+	}
+}
+
+func TestSearchProfilesFixture(t *testing.T) {
+	scraper, transport := twitterscraper.NewFixtureScraper()
+	var profiles []twitterscraper.Profile
+	for profile := range scraper.SearchProfiles(context.Background(), "twitter", 10) {
+		if profile.Error != nil {
+			t.Fatal(profile.Error)
+		}
+		profiles = append(profiles, profile.Profile)
+	}
+
+	expectedIDs := []string{"783214", "2244994945", "17874544"}
+	if len(profiles) != len(expectedIDs) {
+		t.Fatalf("Expected %d profiles, got %d", len(expectedIDs), len(profiles))
+	}
+	for i, id := range expectedIDs {
+		if profiles[i].UserID != id {
+			t.Errorf("Expected profile #%d UserID %s, got %s", i, id, profiles[i].UserID)
+		}
+	}
+
+	joined := time.Date(2007, 2, 20, 14, 35, 54, 0, time.UTC)
+	sample := twitterscraper.Profile{
+		Avatar:           "https://pbs.twimg.com/profile_images/783214/avatar_normal.jpg",
+		Banner:           "https://pbs.twimg.com/profile_banners/783214/1690000000",
+		Biography:        "What's happening?!",
+		FollowersCount:   65000000,
+		FollowingCount:   6,
+		IsVerifiedBlue:   true,
+		Joined:           &joined,
+		LikesCount:       6000,
+		ListedCount:      87000,
+		Location:         "everywhere",
+		Name:             "X",
+		PinnedTweetID:    "1712130000000000001",
+		TweetsCount:      15000,
+		URL:              "https://twitter.com/Twitter",
+		UserID:           "783214",
+		Username:         "Twitter",
+		Website:          "https://twitter.com",
+		ProfessionalType: "Business",
+		ProfessionalDesc: "Social Media Company",
+	}
+	if diff := cmp.Diff(sample, profiles[0]); diff != "" {
+		t.Error("Resulting profile does not match the sample", diff)
+	}
+
+	requests := transport.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(requests))
+	}
+	if product := twitterscraper.FixtureVariables(requests[0])["product"]; product != "People" {
+		t.Errorf("Expected product People, got %v", product)
+	}
+}
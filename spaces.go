@@ -0,0 +1,260 @@
+package twitterscraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Space is an audio conversation hosted by a user.
+// State is one of NotStarted, PrePublished, Running, Ended or Canceled.
+type Space struct {
+	ID                 string
+	Title              string
+	State              string
+	MediaKey           string
+	Created            *time.Time
+	Scheduled          *time.Time
+	Started            *time.Time
+	Ended              *time.Time
+	Host               Profile
+	Admins             []SpaceParticipant
+	Speakers           []SpaceParticipant
+	ParticipantCount   int
+	TotalLiveListeners int
+	TotalReplayWatched int
+	IsReplayAvailable  bool
+	TweetID            string
+	URL                string
+}
+
+// SpaceParticipant is a host, co-host or speaker of the space.
+type SpaceParticipant struct {
+	UserID     string
+	Username   string
+	Name       string
+	Avatar     string
+	IsVerified bool
+	IsMuted    bool
+}
+
+// Broadcast is a live video.
+// State is one of RUNNING, ENDED or TIMED_OUT.
+type Broadcast struct {
+	ID                string
+	Title             string
+	State             string
+	MediaKey          string
+	Created           *time.Time
+	Scheduled         *time.Time
+	Started           *time.Time
+	Ended             *time.Time
+	UserID            string
+	Username          string
+	Name              string
+	Thumbnail         string
+	Width             int
+	Height            int
+	TotalWatched      int
+	TotalWatching     int
+	IsReplayAvailable bool
+	TweetID           string
+	URL               string
+}
+
+type spaceParticipant struct {
+	TwitterScreenName string `json:"twitter_screen_name"`
+	DisplayName       string `json:"display_name"`
+	AvatarURL         string `json:"avatar_url"`
+	IsVerified        bool   `json:"is_verified"`
+	IsMutedByAdmin    bool   `json:"is_muted_by_admin"`
+	IsMutedByGuest    bool   `json:"is_muted_by_guest"`
+	UserResults       struct {
+		RestId string `json:"rest_id"`
+	} `json:"user_results"`
+}
+
+// audioSpace JSON object returned by AudioSpaceById
+type audioSpace struct {
+	Errors []Err `json:"errors"`
+	Data   struct {
+		AudioSpace struct {
+			Metadata struct {
+				RestId                    string      `json:"rest_id"`
+				State                     string      `json:"state"`
+				Title                     string      `json:"title"`
+				MediaKey                  string      `json:"media_key"`
+				CreatedAt                 json.Number `json:"created_at"`
+				ScheduledStart            json.Number `json:"scheduled_start"`
+				StartedAt                 json.Number `json:"started_at"`
+				EndedAt                   json.Number `json:"ended_at"`
+				IsSpaceAvailableForReplay bool        `json:"is_space_available_for_replay"`
+				TotalLiveListeners        int         `json:"total_live_listeners"`
+				TotalReplayWatched        int         `json:"total_replay_watched"`
+				CreatorResults            struct {
+					Result UserResult `json:"result"`
+				} `json:"creator_results"`
+				TweetResults struct {
+					Result tweetResult `json:"result"`
+				} `json:"tweet_results"`
+			} `json:"metadata"`
+			Participants struct {
+				Total    int                `json:"total"`
+				Admins   []spaceParticipant `json:"admins"`
+				Speakers []spaceParticipant `json:"speakers"`
+			} `json:"participants"`
+		} `json:"audioSpace"`
+	} `json:"data"`
+}
+
+// broadcastShow JSON object returned by broadcasts/show
+type broadcastShow struct {
+	Broadcasts map[string]struct {
+		ID                 string      `json:"id"`
+		Status             string      `json:"status"`
+		State              string      `json:"state"`
+		MediaKey           string      `json:"media_key"`
+		CreatedAtMs        json.Number `json:"created_at_ms"`
+		ScheduledStartMs   json.Number `json:"scheduled_start_ms"`
+		StartMs            json.Number `json:"start_ms"`
+		EndMs              json.Number `json:"end_ms"`
+		UserID             string      `json:"twitter_user_id"`
+		TwitterUsername    string      `json:"twitter_username"`
+		UserDisplayName    string      `json:"user_display_name"`
+		ImageURL           string      `json:"image_url"`
+		Width              int         `json:"width"`
+		Height             int         `json:"height"`
+		TotalWatched       json.Number `json:"total_watched"`
+		TotalWatching      json.Number `json:"total_watching"`
+		AvailableForReplay bool        `json:"available_for_replay"`
+		TweetID            string      `json:"tweet_id"`
+	} `json:"broadcasts"`
+}
+
+// GetSpace return space metadata and participants.
+func (s *Scraper) GetSpace(ctx context.Context, spaceID string) (Space, error) {
+	variables := map[string]interface{}{
+		"id":              spaceID,
+		"isMetatagsQuery": false,
+		"withReplays":     true,
+		"withListeners":   true,
+	}
+
+	req, err := s.newGraphQLRequest("jyQ0_DEMZHeoluCgHJ-U5Q/AudioSpaceById", variables)
+	if err != nil {
+		return Space{}, err
+	}
+	req = req.WithContext(ctx)
+
+	var jsn audioSpace
+	err = s.RequestAPI(req, &jsn)
+	if err != nil {
+		return Space{}, err
+	}
+
+	space := jsn.Data.AudioSpace
+	if space.Metadata.RestId == "" {
+		if len(jsn.Errors) > 0 {
+			return Space{}, jsn.Errors[0]
+		}
+		return Space{}, fmt.Errorf("space %s not found", spaceID)
+	}
+
+	sp := Space{
+		ID:                 space.Metadata.RestId,
+		Title:              space.Metadata.Title,
+		State:              space.Metadata.State,
+		MediaKey:           space.Metadata.MediaKey,
+		Created:            msecToTime(space.Metadata.CreatedAt),
+		Scheduled:          msecToTime(space.Metadata.ScheduledStart),
+		Started:            msecToTime(space.Metadata.StartedAt),
+		Ended:              msecToTime(space.Metadata.EndedAt),
+		ParticipantCount:   space.Participants.Total,
+		TotalLiveListeners: space.Metadata.TotalLiveListeners,
+		TotalReplayWatched: space.Metadata.TotalReplayWatched,
+		IsReplayAvailable:  space.Metadata.IsSpaceAvailableForReplay,
+		TweetID:            space.Metadata.TweetResults.Result.RestId,
+		URL:                "https://twitter.com/i/spaces/" + space.Metadata.RestId,
+	}
+
+	if space.Metadata.CreatorResults.Result.RestId != "" {
+		sp.Host = parseProfile(space.Metadata.CreatorResults.Result)
+	}
+	for _, p := range space.Participants.Admins {
+		sp.Admins = append(sp.Admins, parseSpaceParticipant(p))
+	}
+	for _, p := range space.Participants.Speakers {
+		sp.Speakers = append(sp.Speakers, parseSpaceParticipant(p))
+	}
+
+	return sp, nil
+}
+
+// GetBroadcast return live video metadata.
+func (s *Scraper) GetBroadcast(ctx context.Context, broadcastID string) (Broadcast, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://twitter.com/i/api/1.1/broadcasts/show.json", nil)
+	if err != nil {
+		return Broadcast{}, err
+	}
+	req.URL.RawQuery = url.Values{"ids": {broadcastID}, "include_events": {"true"}}.Encode()
+
+	var jsn broadcastShow
+	err = s.RequestAPI(req, &jsn)
+	if err != nil {
+		return Broadcast{}, err
+	}
+
+	broadcast, ok := jsn.Broadcasts[broadcastID]
+	if !ok {
+		return Broadcast{}, fmt.Errorf("broadcast %s not found", broadcastID)
+	}
+
+	totalWatched, _ := broadcast.TotalWatched.Int64()
+	totalWatching, _ := broadcast.TotalWatching.Int64()
+
+	return Broadcast{
+		ID:                broadcast.ID,
+		Title:             broadcast.Status,
+		State:             broadcast.State,
+		MediaKey:          broadcast.MediaKey,
+		Created:           msecToTime(broadcast.CreatedAtMs),
+		Scheduled:         msecToTime(broadcast.ScheduledStartMs),
+		Started:           msecToTime(broadcast.StartMs),
+		Ended:             msecToTime(broadcast.EndMs),
+		UserID:            broadcast.UserID,
+		Username:          broadcast.TwitterUsername,
+		Name:              broadcast.UserDisplayName,
+		Thumbnail:         broadcast.ImageURL,
+		Width:             broadcast.Width,
+		Height:            broadcast.Height,
+		TotalWatched:      int(totalWatched),
+		TotalWatching:     int(totalWatching),
+		IsReplayAvailable: broadcast.AvailableForReplay,
+		TweetID:           broadcast.TweetID,
+		URL:               "https://twitter.com/i/broadcasts/" + broadcast.ID,
+	}, nil
+}
+
+func parseSpaceParticipant(p spaceParticipant) SpaceParticipant {
+	return SpaceParticipant{
+		UserID:     p.UserResults.RestId,
+		Username:   p.TwitterScreenName,
+		Name:       p.DisplayName,
+		Avatar:     p.AvatarURL,
+		IsVerified: p.IsVerified,
+		IsMuted:    p.IsMutedByAdmin || p.IsMutedByGuest,
+	}
+}
+
+// msecToTime converts milliseconds since epoch, zero or empty value is nil
+func msecToTime(msec json.Number) *time.Time {
+	ms, err := msec.Int64()
+	if err != nil || ms <= 0 {
+		return nil
+	}
+	tm := time.Unix(0, ms*int64(time.Millisecond)).UTC()
+	return &tm
+}
@@ -0,0 +1,82 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+
+	twitterscraper "github.com/n0madic/twitter-scraper"
+)
+
+func TestGetSpaceFixture(t *testing.T) {
+	scraper, transport := twitterscraper.NewFixtureScraper()
+	space, err := scraper.GetSpace(context.Background(), "1YqKDqDXAbwKV")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if space.ID != "1YqKDqDXAbwKV" || space.Title != "Twitter Spaces AMA" || space.State != "Ended" {
+		t.Errorf("Unexpected space %s %s %s", space.ID, space.Title, space.State)
+	}
+	if space.Host.Username != "Twitter" {
+		t.Errorf("Expected space host Twitter, got %s", space.Host.Username)
+	}
+	if len(space.Admins) != 1 || space.Admins[0].Username != "Twitter" {
+		t.Errorf("Unexpected space admins %v", space.Admins)
+	}
+	if len(space.Speakers) != 1 || space.Speakers[0].UserID != "2244994945" || !space.Speakers[0].IsMuted {
+		t.Errorf("Unexpected space speakers %v", space.Speakers)
+	}
+	if space.ParticipantCount != 1302 || space.TotalReplayWatched != 4200 || !space.IsReplayAvailable {
+		t.Errorf("Unexpected space stats %d/%d/%v", space.ParticipantCount, space.TotalReplayWatched, space.IsReplayAvailable)
+	}
+	if space.Started == nil || space.Started.Unix() != 1696863605 || space.Ended == nil || space.Ended.Unix() != 1696870805 {
+		t.Errorf("Unexpected space times %v - %v", space.Started, space.Ended)
+	}
+	if space.TweetID != "1712200000000000001" {
+		t.Errorf("Expected space TweetID 1712200000000000001, got %s", space.TweetID)
+	}
+	if id := twitterscraper.FixtureVariables(transport.Requests()[0])["id"]; id != "1YqKDqDXAbwKV" {
+		t.Errorf("Expected id 1YqKDqDXAbwKV, got %v", id)
+	}
+}
+
+func TestGetBroadcastFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	broadcast, err := scraper.GetBroadcast(context.Background(), "1OdJrBWOXqVJX")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if broadcast.Title != "Live from HQ" || broadcast.State != "ENDED" || broadcast.Username != "Twitter" {
+		t.Errorf("Unexpected broadcast %s %s %s", broadcast.Title, broadcast.State, broadcast.Username)
+	}
+	if broadcast.TotalWatched != 98765 || broadcast.Width != 1280 || broadcast.Scheduled != nil {
+		t.Errorf("Unexpected broadcast %d %d %v", broadcast.TotalWatched, broadcast.Width, broadcast.Scheduled)
+	}
+	if broadcast.TweetID != "1711500000000000004" {
+		t.Errorf("Expected broadcast TweetID 1711500000000000004, got %s", broadcast.TweetID)
+	}
+
+	_, err = scraper.GetBroadcast(context.Background(), "unknown")
+	if err == nil {
+		t.Error("Expected error for unknown broadcast")
+	}
+}
+
+func TestTweetSpaceAndBroadcastFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	links := map[string][2]string{}
+	for tweet := range scraper.GetTweets(context.Background(), "Twitter", 10) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		links[tweet.ID] = [2]string{tweet.SpaceID, tweet.BroadcastID}
+	}
+	if links["1712200000000000001"] != [2]string{"1YqKDqDXAbwKV", ""} {
+		t.Errorf("Unexpected space link %v", links["1712200000000000001"])
+	}
+	if links["1711500000000000004"] != [2]string{"", "1OdJrBWOXqVJX"} {
+		t.Errorf("Unexpected broadcast link %v", links["1711500000000000004"])
+	}
+	if links["1712100000000000002"] != [2]string{} {
+		t.Errorf("Unexpected links %v", links["1712100000000000002"])
+	}
+}
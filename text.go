@@ -0,0 +1,74 @@
+package twitterscraper
+
+import (
+	"html/template"
+	"net/url"
+	"strings"
+)
+
+// HTMLLink is passed to the link template of the tweet HTML.
+type HTMLLink struct {
+	Type SegmentType
+	URL  string
+	Text string
+}
+
+// defaultLinkTemplate renders links like the web client
+var defaultLinkTemplate = template.Must(template.New("link").Parse(`<a href="{{.URL}}">{{.Text}}</a>`))
+
+// HTML returns the displayed text of the tweet as HTML with links of mentions, hashtags, cashtags and URLs.
+func (tweet *Tweet) HTML() string {
+	s, _ := tweet.RenderHTML(defaultLinkTemplate)
+	return s
+}
+
+// RenderHTML returns the displayed text of the tweet as HTML with links rendered by the template,
+// which is executed with HTMLLink. Text is escaped and line breaks are replaced by <br>.
+func (tweet *Tweet) RenderHTML(tpl *template.Template) (string, error) {
+	var b strings.Builder
+	for _, segment := range tweet.Segments {
+		link := HTMLLink{Type: segment.Type, Text: segment.Text}
+		switch segment.Type {
+		case SegmentMention:
+			link.URL = "https://twitter.com/" + segment.Username
+		case SegmentHashtag:
+			link.URL = "https://twitter.com/hashtag/" + url.PathEscape(segment.Tag)
+		case SegmentCashtag:
+			link.URL = "https://twitter.com/search?q=" + url.QueryEscape("$"+segment.Tag)
+		case SegmentURL, SegmentMedia:
+			link.URL = segment.ExpandedURL
+			if segment.DisplayURL != "" {
+				link.Text = segment.DisplayURL
+			}
+		}
+		if link.URL == "" {
+			b.WriteString(strings.Replace(template.HTMLEscapeString(segment.Text), "\n", "<br>", -1))
+			continue
+		}
+		err := tpl.Execute(&b, link)
+		if err != nil {
+			return "", err
+		}
+	}
+	return b.String(), nil
+}
+
+// expandText returns unescaped display text with expanded URLs, without leading mentions
+// of the reply and links of media
+func expandText(text string, ents entities, displayTextRange []int) string {
+	var b strings.Builder
+	for _, segment := range parseSegments(text, ents, displayTextRange) {
+		switch segment.Type {
+		case SegmentMedia:
+		case SegmentURL:
+			if segment.ExpandedURL != "" {
+				b.WriteString(segment.ExpandedURL)
+			} else {
+				b.WriteString(segment.Text)
+			}
+		default:
+			b.WriteString(segment.Text)
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
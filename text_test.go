@@ -0,0 +1,56 @@
+package twitterscraper_test
+
+import (
+	"html/template"
+	"testing"
+
+	twitterscraper "github.com/n0madic/twitter-scraper"
+)
+
+func TestTweetTextFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	tweets, _, err := scraper.FetchTweetsAndReplies("Twitter", 20, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tweets) != 2 {
+		t.Fatalf("Expected 2 tweets, got %d", len(tweets))
+	}
+	reply := tweets[1]
+
+	expected := "Answer & more 🚀 #golang $TWTR https://go.dev/blog"
+	if reply.ExpandedText != expected {
+		t.Errorf("Expected ExpandedText %q, got %q", expected, reply.ExpandedText)
+	}
+
+	expected = `Answer &amp; more 🚀 <a href="https://twitter.com/hashtag/golang">#golang</a> ` +
+		`<a href="https://twitter.com/search?q=%24TWTR">$TWTR</a> <a href="https://go.dev/blog">go.dev/blog</a>`
+	if html := reply.HTML(); html != expected {
+		t.Errorf("Expected HTML %q, got %q", expected, html)
+	}
+}
+
+func TestTweetHTML(t *testing.T) {
+	tweet := twitterscraper.Tweet{Segments: []twitterscraper.TextSegment{
+		{Type: twitterscraper.SegmentText, Text: "<b>bold</b>\nby "},
+		{Type: twitterscraper.SegmentMention, Text: "@Twitter", Username: "Twitter"},
+		{Type: twitterscraper.SegmentText, Text: " "},
+		{Type: twitterscraper.SegmentURL, Text: "https://t.co/abc", ExpandedURL: "javascript:alert(1)", DisplayURL: "\"quoted\""},
+	}}
+
+	expected := `&lt;b&gt;bold&lt;/b&gt;<br>by <a href="https://twitter.com/Twitter">@Twitter</a> <a href="#ZgotmplZ">&#34;quoted&#34;</a>`
+	if html := tweet.HTML(); html != expected {
+		t.Errorf("Expected HTML %q, got %q", expected, html)
+	}
+
+	tpl := template.Must(template.New("link").Parse(`<a class="link" href="{{.URL}}" target="_blank">{{.Text}}</a>`))
+	html, err := tweet.RenderHTML(tpl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = `&lt;b&gt;bold&lt;/b&gt;<br>by <a class="link" href="https://twitter.com/Twitter" target="_blank">@Twitter</a> ` +
+		`<a class="link" href="#ZgotmplZ" target="_blank">&#34;quoted&#34;</a>`
+	if html != expected {
+		t.Errorf("Expected HTML %q, got %q", expected, html)
+	}
+}
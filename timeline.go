@@ -1,6 +1,9 @@
 package twitterscraper
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -8,51 +11,34 @@ import (
 type timeline struct {
 	GlobalObjects struct {
 		Tweets map[string]struct {
-			ConversationIDStr string `json:"conversation_id_str"`
-			CreatedAt         string `json:"created_at"`
-			FavoriteCount     int    `json:"favorite_count"`
-			FullText          string `json:"full_text"`
-			Entities          struct {
-				Hashtags []struct {
-					Text string `json:"text"`
-				} `json:"hashtags"`
-				Media []struct {
-					MediaURLHttps string `json:"media_url_https"`
-					Type          string `json:"type"`
-					URL           string `json:"url"`
-				} `json:"media"`
-				URLs []struct {
-					ExpandedURL string `json:"expanded_url"`
-					URL         string `json:"url"`
-				} `json:"urls"`
-			} `json:"entities"`
-			ExtendedEntities struct {
-				Media []struct {
-					IDStr                    string `json:"id_str"`
-					MediaURLHttps            string `json:"media_url_https"`
-					ExtSensitiveMediaWarning struct {
-						AdultContent    bool `json:"adult_content"`
-						GraphicViolence bool `json:"graphic_violence"`
-						Other           bool `json:"other"`
-					} `json:"ext_sensitive_media_warning"`
-					Type      string `json:"type"`
-					URL       string `json:"url"`
-					VideoInfo struct {
-						Variants []struct {
-							Bitrate int    `json:"bitrate,omitempty"`
-							URL     string `json:"url"`
-						} `json:"variants"`
-					} `json:"video_info"`
-				} `json:"media"`
+			ConversationIDStr string   `json:"conversation_id_str"`
+			CreatedAt         string   `json:"created_at"`
+			FavoriteCount     int      `json:"favorite_count"`
+			FullText          string   `json:"full_text"`
+			Entities          entities `json:"entities"`
+			DisplayTextRange  []int    `json:"display_text_range"`
+			ExtendedEntities  struct {
+				Media []Media `json:"media"`
 			} `json:"extended_entities"`
-			InReplyToStatusIDStr string    `json:"in_reply_to_status_id_str"`
-			Place                Place     `json:"place"`
-			ReplyCount           int       `json:"reply_count"`
-			RetweetCount         int       `json:"retweet_count"`
-			RetweetedStatusIDStr string    `json:"retweeted_status_id_str"`
-			QuotedStatusIDStr    string    `json:"quoted_status_id_str"`
-			Time                 time.Time `json:"time"`
-			UserIDStr            string    `json:"user_id_str"`
+			InReplyToStatusIDStr      string    `json:"in_reply_to_status_id_str"`
+			Place                     Place     `json:"place"`
+			ReplyCount                int       `json:"reply_count"`
+			RetweetCount              int       `json:"retweet_count"`
+			RetweetedStatusIDStr      string    `json:"retweeted_status_id_str"`
+			QuoteCount                int       `json:"quote_count"`
+			BookmarkCount             int       `json:"bookmark_count"`
+			Lang                      string    `json:"lang"`
+			Source                    string    `json:"source"`
+			IsQuoteStatus             bool      `json:"is_quote_status"`
+			PossiblySensitive         bool      `json:"possibly_sensitive"`
+			PossiblySensitiveEditable bool      `json:"possibly_sensitive_editable"`
+			QuotedStatusIDStr         string    `json:"quoted_status_id_str"`
+			Time                      time.Time `json:"time"`
+			UserIDStr                 string    `json:"user_id_str"`
+			ExtViews                  struct {
+				Count string `json:"count"`
+				State string `json:"state"`
+			} `json:"ext_views"`
 		} `json:"tweets"`
 		Users map[string]struct {
 			CreatedAt   string `json:"created_at"`
@@ -150,168 +136,202 @@ type timeline struct {
 	} `json:"timeline"`
 }
 
-//func (timeline *timeline) parseTweet(id string) *Tweet {
-//	if tweet, ok := timeline.GlobalObjects.Tweets[id]; ok {
-//		username := timeline.GlobalObjects.Users[tweet.UserIDStr].ScreenName
-//		tw := &Tweet{
-//			ID:           id,
-//			Likes:        tweet.FavoriteCount,
-//			PermanentURL: fmt.Sprintf("https://twitter.com/%s/status/%s", username, id),
-//			Replies:      tweet.ReplyCount,
-//			Retweets:     tweet.RetweetCount,
-//			Text:         tweet.FullText,
-//			UserID:       tweet.UserIDStr,
-//			Username:     username,
-//		}
-//
-//		tm, err := time.Parse(time.RubyDate, tweet.CreatedAt)
-//		if err == nil {
-//			tw.TimeParsed = tm
-//			tw.Timestamp = tm.Unix()
-//		}
-//
-//		if tweet.QuotedStatusIDStr != "" {
-//			tw.QuoteRetweetId = timeline.parseTweet(tweet.QuotedStatusIDStr).ID
-//		}
-//		if tweet.InReplyToStatusIDStr != "" {
-//			tw.IsReply = true
-//			tw.ReplyingTo = timeline.parseTweet(tweet.InReplyToStatusIDStr).ID
-//		}
-//		if tweet.RetweetedStatusIDStr != "" {
-//			tw.IsRetweet = true
-//		}
-//
-//		for _, pinned := range timeline.GlobalObjects.Users[tweet.UserIDStr].PinnedTweetIdsStr {
-//			if tweet.ConversationIDStr == pinned {
-//				tw.IsPin = true
-//				break
-//			}
-//		}
-//
-//		for _, hash := range tweet.Entities.Hashtags {
-//			tw.Hashtags = append(tw.Hashtags, hash.Text)
-//		}
-//
-//		for _, media := range tweet.ExtendedEntities.Media {
-//			if media.Type == "photo" {
-//				tw.Photos = append(tw.Photos, media.MediaURLHttps)
-//			} else if media.Type == "video" {
-//				video := Video{
-//					ID:      media.IDStr,
-//					Preview: media.MediaURLHttps,
-//				}
-//
-//				maxBitrate := 0
-//				for _, variant := range media.VideoInfo.Variants {
-//					if variant.Bitrate > maxBitrate {
-//						video.URL = strings.TrimSuffix(variant.URL, "?tag=10")
-//						maxBitrate = variant.Bitrate
-//					}
-//				}
-//
-//				tw.Photos = append(tw.Photos, video.Preview)
-//				tw.Videos = append(tw.Videos, video)
-//			}
-//
-//			if !tw.SensitiveContent {
-//				sensitive := media.ExtSensitiveMediaWarning
-//				tw.SensitiveContent = sensitive.AdultContent || sensitive.GraphicViolence || sensitive.Other
-//			}
-//		}
-//
-//		for _, url := range tweet.Entities.URLs {
-//			tw.URLs = append(tw.URLs, url.ExpandedURL)
-//		}
-//
-//		tw.HTML = tweet.FullText
-//		tw.HTML = reHashtag.ReplaceAllStringFunc(tw.HTML, func(hashtag string) string {
-//			return fmt.Sprintf(`<a href="https://twitter.com/hashtag/%s">%s</a>`,
-//				strings.TrimPrefix(hashtag, "#"),
-//				hashtag,
-//			)
-//		})
-//		tw.HTML = reUsername.ReplaceAllStringFunc(tw.HTML, func(username string) string {
-//			return fmt.Sprintf(`<a href="https://twitter.com/%s">%s</a>`,
-//				strings.TrimPrefix(username, "@"),
-//				username,
-//			)
-//		})
-//		var foundedMedia []string
-//		tw.HTML = reTwitterURL.ReplaceAllStringFunc(tw.HTML, func(tco string) string {
-//			for _, entity := range tweet.Entities.URLs {
-//				if tco == entity.URL {
-//					return fmt.Sprintf(`<a href="%s">%s</a>`, entity.ExpandedURL, tco)
-//				}
-//			}
-//			for _, entity := range tweet.ExtendedEntities.Media {
-//				if tco == entity.URL {
-//					foundedMedia = append(foundedMedia, entity.MediaURLHttps)
-//					return fmt.Sprintf(`<br><a href="%s"><img src="%s"/></a>`, tco, entity.MediaURLHttps)
-//				}
-//			}
-//			return tco
-//		})
-//		for _, url := range tw.Photos {
-//			if stringInSlice(url, foundedMedia) {
-//				continue
-//			}
-//			tw.HTML += fmt.Sprintf(`<br><img src="%s"/>`, url)
-//		}
-//		tw.HTML = strings.Replace(tw.HTML, "\n", "<br>", -1)
-//		return tw
-//	}
-//	return nil
-//}
-//
-//func (timeline *timeline) parseTweets() ([]*Tweet, string) {
-//	var cursor string
-//	var pinnedTweet *Tweet
-//	var orderedTweets []*Tweet
-//	for _, instruction := range timeline.Timeline.Instructions {
-//		if instruction.PinEntry.Entry.Content.Item.Content.Tweet.ID != "" {
-//			if tweet := timeline.parseTweet(instruction.PinEntry.Entry.Content.Item.Content.Tweet.ID); tweet != nil {
-//				pinnedTweet = tweet
-//			}
-//		}
-//		for _, entry := range instruction.AddEntries.Entries {
-//			if tweet := timeline.parseTweet(entry.Content.Item.Content.Tweet.ID); tweet != nil {
-//				orderedTweets = append(orderedTweets, tweet)
-//			}
-//			if entry.Content.Operation.Cursor.CursorType == "Bottom" {
-//				cursor = entry.Content.Operation.Cursor.Value
-//			}
-//		}
-//		if instruction.ReplaceEntry.Entry.Content.Operation.Cursor.CursorType == "Bottom" {
-//			cursor = instruction.ReplaceEntry.Entry.Content.Operation.Cursor.Value
-//		}
-//	}
-//	if pinnedTweet != nil && len(orderedTweets) > 0 {
-//		orderedTweets = append([]*Tweet{pinnedTweet}, orderedTweets...)
-//	}
-//	return orderedTweets, cursor
-//}
+// parseTweet returns tweet of the legacy timeline by ID or nil if it is missing
+func (timeline *timeline) parseTweet(id string) *Tweet {
+	tweet, ok := timeline.GlobalObjects.Tweets[id]
+	if !ok {
+		return nil
+	}
+
+	username := timeline.GlobalObjects.Users[tweet.UserIDStr].ScreenName
+	tw := &Tweet{
+		ID:                  id,
+		ConversationID:      tweet.ConversationIDStr,
+		QuoteRetweetId:      tweet.QuotedStatusIDStr,
+		ReplyingTo:          tweet.InReplyToStatusIDStr,
+		IsReply:             tweet.InReplyToStatusIDStr != "",
+		IsRetweet:           tweet.RetweetedStatusIDStr != "",
+		RetweetedStatusID:   tweet.RetweetedStatusIDStr,
+		Likes:               tweet.FavoriteCount,
+		PermanentURL:        fmt.Sprintf("https://twitter.com/%s/status/%s", username, id),
+		Media:               tweet.ExtendedEntities.Media,
+		Replies:             tweet.ReplyCount,
+		Retweets:            tweet.RetweetCount,
+		QuoteRetweets:       tweet.QuoteCount,
+		SensitiveContent:    tweet.PossiblySensitive,
+		IsSensitiveEditable: tweet.PossiblySensitiveEditable,
+		Lang:                tweet.Lang,
+		Bookmarks:           tweet.BookmarkCount,
+		Text:                tweet.FullText,
+		UserID:              tweet.UserIDStr,
+		Username:            username,
+	}
+
+	tm, err := time.Parse(time.RubyDate, tweet.CreatedAt)
+	if err == nil {
+		tw.TimeParsed = tm.UTC()
+		tw.Timestamp = tm.Unix()
+	}
 
-// func (timeline *timeline) parseUsers() ([]*Profile, string) {
-// 	users := make(map[string]Profile)
+	if tweet.RetweetedStatusIDStr != "" {
+		tw.RetweetedStatus = timeline.parseTweet(tweet.RetweetedStatusIDStr)
+	}
 
-// 	for id, user := range timeline.GlobalObjects.Users {
-// 		users[id] = parseProfile(user)
-// 	}
+	tw.Views, _ = strconv.Atoi(tweet.ExtViews.Count)
+	tw.ViewsState = tweet.ExtViews.State
+	tw.Source, tw.SourceURL = parseSource(tweet.Source)
+	tw.IsQuoted = tweet.IsQuoteStatus || tweet.QuotedStatusIDStr != ""
+	if tweet.QuotedStatusIDStr != "" {
+		tw.QuotedStatus = timeline.parseTweet(tweet.QuotedStatusIDStr)
+		// deleted or protected quoted tweet is missing in global objects
+		if tw.QuotedStatus == nil {
+			tw.QuotedStatus = &Tweet{ID: tweet.QuotedStatusIDStr, IsTombstone: true}
+		}
+	}
+
+	parseEntities(tw, tweet.Entities)
+	tw.Segments = parseSegments(tweet.FullText, tweet.Entities, tweet.DisplayTextRange)
+	tw.ExpandedText = expandText(tweet.FullText, tweet.Entities, tweet.DisplayTextRange)
+	parseMedia(tw, tw.Media)
+
+	return tw
+}
 
-// 	var cursor string
-// 	var orderedProfiles []*Profile
-// 	for _, instruction := range timeline.Timeline.Instructions {
-// 		for _, entry := range instruction.AddEntries.Entries {
-// 			if profile, ok := users[entry.Content.Item.Content.User.ID]; ok {
-// 				orderedProfiles = append(orderedProfiles, &profile)
-// 			}
-// 			if entry.Content.Operation.Cursor.CursorType == "Bottom" {
-// 				cursor = entry.Content.Operation.Cursor.Value
-// 			}
-// 		}
-// 		if instruction.ReplaceEntry.Entry.Content.Operation.Cursor.CursorType == "Bottom" {
-// 			cursor = instruction.ReplaceEntry.Entry.Content.Operation.Cursor.Value
-// 		}
-// 	}
-// 	return orderedProfiles, cursor
-// }
+// parseTweets returns tweets of the legacy timeline in order and the bottom cursor
+func (timeline *timeline) parseTweets() ([]*Tweet, string) {
+	var cursor string
+	var orderedTweets []*Tweet
+	for _, instruction := range timeline.Timeline.Instructions {
+		for _, entry := range instruction.AddEntries.Entries {
+			if tweet := timeline.parseTweet(entry.Content.Item.Content.Tweet.ID); tweet != nil {
+				orderedTweets = append(orderedTweets, tweet)
+			}
+			if entry.Content.Operation.Cursor.CursorType == "Bottom" {
+				cursor = entry.Content.Operation.Cursor.Value
+			}
+		}
+		if instruction.ReplaceEntry.Entry.Content.Operation.Cursor.CursorType == "Bottom" {
+			cursor = instruction.ReplaceEntry.Entry.Content.Operation.Cursor.Value
+		}
+	}
+	return orderedTweets, cursor
+}
+
+// timelineV2 JSON object returned by the GraphQL timelines
+type timelineV2 struct {
+	Instructions []instrutions `json:"instructions"`
+}
+
+// itemContents returns the item contents of a single entry or of a module entry
+func (entry *recursivetimelineentry) itemContents() []itemcontent {
+	if len(entry.Content.Items) == 0 {
+		return []itemcontent{entry.Content.ItemContent}
+	}
+	var contents []itemcontent
+	for _, item := range entry.Content.Items {
+		contents = append(contents, item.Item.ItemContent)
+	}
+	return contents
+}
+
+// timelineEntries returns entries of the instruction, the single entry of replace and pin
+// instructions goes first
+func (instruction *instrutions) timelineEntries() []recursivetimelineentry {
+	if instruction.Entry.EntryId == "" {
+		return instruction.Entries
+	}
+	return append([]recursivetimelineentry{instruction.Entry}, instruction.Entries...)
+}
+
+// bottomCursor returns value of the bottom cursor if entry is one
+func (entry *recursivetimelineentry) bottomCursor() string {
+	if entry.Content.CursorType == "Bottom" {
+		return entry.Content.Value
+	}
+	if entry.Content.ItemContent.Cursor == "Bottom" {
+		return entry.Content.ItemContent.Value
+	}
+	return ""
+}
+
+func (tl *timelineV2) parseTweets() ([]*Tweet, string) {
+	var cursor string
+	var pinnedTweet *Tweet
+	var orderedTweets []*Tweet
+	for _, instruction := range tl.Instructions {
+		entries := instruction.timelineEntries()
+		if len(instruction.ModuleItems) > 0 {
+			var entry recursivetimelineentry
+			entry.Content.Items = instruction.ModuleItems
+			entries = append(entries, entry)
+		}
+		for _, entry := range entries {
+			if bottom := entry.bottomCursor(); bottom != "" {
+				cursor = bottom
+				continue
+			}
+			if strings.HasPrefix(entry.EntryId, "promoted") {
+				continue
+			}
+			for _, content := range entry.itemContents() {
+				tweet, err := ItemContentToTweet(content)
+				if err != nil {
+					continue
+				}
+				if instruction.Type == "TimelinePinEntry" {
+					tweet.IsPin = true
+					pinnedTweet = &tweet
+					continue
+				}
+				orderedTweets = append(orderedTweets, &tweet)
+			}
+		}
+	}
+	if pinnedTweet != nil && len(orderedTweets) > 0 {
+		orderedTweets = append([]*Tweet{pinnedTweet}, orderedTweets...)
+	}
+	return orderedTweets, cursor
+}
+
+func (tl *timelineV2) parseUsers() ([]*Profile, string) {
+	var cursor string
+	var orderedProfiles []*Profile
+	for _, instruction := range tl.Instructions {
+		for _, entry := range instruction.timelineEntries() {
+			if bottom := entry.bottomCursor(); bottom != "" {
+				cursor = bottom
+				continue
+			}
+			for _, content := range entry.itemContents() {
+				user := content.UserResults.Result
+				if user.TypeName != "User" || user.RestId == "" {
+					continue
+				}
+				profile := parseProfile(user)
+				orderedProfiles = append(orderedProfiles, &profile)
+			}
+		}
+	}
+	return orderedProfiles, cursor
+}
+
+func (tl *timelineV2) parseLists() ([]*List, string) {
+	var cursor string
+	var orderedLists []*List
+	for _, instruction := range tl.Instructions {
+		for _, entry := range instruction.Entries {
+			if bottom := entry.bottomCursor(); bottom != "" {
+				cursor = bottom
+				continue
+			}
+			for _, content := range entry.itemContents() {
+				if content.List.IDStr == "" {
+					continue
+				}
+				list := parseList(content.List)
+				orderedLists = append(orderedLists, &list)
+			}
+		}
+	}
+	return orderedLists, cursor
+}
@@ -1,14 +1,17 @@
 package twitterscraper
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"html"
 	"strconv"
 	"strings"
 	"time"
 )
 
 type timelinerecursive struct {
-	Errors []Err `json:"error"`
+	Errors []Err `json:"errors"`
 	Data   struct {
 		ThreadedConvo struct {
 			Instructions []instrutions `json:"instructions"`
@@ -18,13 +21,22 @@ type timelinerecursive struct {
 
 type Err struct {
 	Code    int    `json:"code"`
+	Kind    string `json:"kind"`
 	Message string `json:"message"`
 }
 
+func (e Err) Error() string {
+	return e.Message
+}
+
 type instrutions struct {
 	Type      string                   `json:"type"`
 	Direction string                   `json:"direction"`
 	Entries   []recursivetimelineentry `json:"entries"`
+	Entry     recursivetimelineentry   `json:"entry"`
+
+	// TimelineAddToModule appends items to module of the previous page
+	ModuleItems []items `json:"moduleItems"`
 }
 
 type recursivetimelineentry struct {
@@ -35,6 +47,10 @@ type recursivetimelineentry struct {
 		TypeName    string      `json:"__typename"`
 		ItemContent itemcontent `json:"itemContent"`
 		Items       []items     `json:"items"`
+
+		// cursor
+		Value      string `json:"value"`
+		CursorType string `json:"cursorType"`
 	} `json:"content"`
 }
 
@@ -49,84 +65,133 @@ type itemcontent struct {
 	ItemType     string `json:"itemType"`
 	TypeName     string `json:"__typename"`
 	TweetResults struct {
-		Result struct {
-			TypeName string `json:"__typename"`
-			RestId   string `json:"rest_id"`
-			Core     struct {
-				UserResults struct {
-					Result UserResult `json:"result"`
-				} `json:"user_results"`
-			} `json:"core"`
-
-			Card Card `json:"card"`
-
-			EditControl struct {
-				InitialTweetId string   `json:"initial_tweet_id"`
-				EditIds        []string `json:"edit_tweet_ids"`
-				EditableUntil  string   `json:"editable_until_msecs"`
-				IsEditable     bool     `json:"is_edit_eligible"`
-			}
-
-			Legacy struct {
-				ConversationIDStr string `json:"conversation_id_str"`
-				CreatedAt         string `json:"created_at"`
-				FavoriteCount     int    `json:"favorite_count"`
-				FullText          string `json:"full_text"`
-				Entities          struct {
-					Hashtags []struct {
-						Text string `json:"text"`
-					} `json:"hashtags"`
-					Media []struct {
-						MediaURLHttps string `json:"media_url_https"`
-						Type          string `json:"type"`
-						URL           string `json:"url"`
-					} `json:"media"`
-					URLs []struct {
-						ExpandedURL string `json:"expanded_url"`
-						URL         string `json:"url"`
-					} `json:"urls"`
-					UserMentions []struct {
-						IdStr      string `json:"id_str"`
-						Name       string `json:"name"`
-						ScreenName string `json:"screen_name"`
-					} `json:"user_mentions"`
-				} `json:"entities"`
-				ExtendedEntities struct {
-					Media []Media `json:"media"`
-				} `json:"extended_entities"`
-				InReplyToStatusIDStr string    `json:"in_reply_to_status_id_str"`
-				Place                Place     `json:"place"`
-				ReplyCount           int       `json:"reply_count"`
-				RetweetCount         int       `json:"retweet_count"`
-				RetweetedStatusIDStr string    `json:"retweeted_status_id_str"`
-				QuoteCount           int       `json:"quote_count"`
-				PossiblySensitive    bool      `json:"possibly_sensitive"`
-				QuotedStatusIDStr    string    `json:"quoted_status_id_str"`
-				Time                 time.Time `json:"time"`
-				UserIDStr            string    `json:"user_id_str"`
-			} `json:"legacy"`
-			HasModeratedReplies bool `json:"hasModeratedReplies"`
-		} `json:"result"`
+		Result tweetResult `json:"result"`
 	} `json:"tweet_results"`
+	UserResults struct {
+		Result UserResult `json:"result"`
+	} `json:"user_results"`
+	List listResult `json:"list"`
 
 	// cursor
 	Value  string `json:"value"`
 	Cursor string `json:"cursorType"`
 }
 
+type tweetResult struct {
+	TypeName string `json:"__typename"`
+	RestId   string `json:"rest_id"`
+	Core     struct {
+		UserResults struct {
+			Result UserResult `json:"result"`
+		} `json:"user_results"`
+	} `json:"core"`
+
+	Card Card `json:"card"`
+
+	Views struct {
+		Count string `json:"count"`
+		State string `json:"state"`
+	} `json:"views"`
+	Source string `json:"source"`
+
+	// TweetWithVisibilityResults restricts actions with the tweet
+	LimitedActionResults struct {
+		LimitedActions []struct {
+			Action string `json:"action"`
+		} `json:"limited_actions"`
+	} `json:"limitedActionResults"`
+
+	BirdwatchPivot birdwatchPivot `json:"birdwatch_pivot"`
+
+	CommunityResults struct {
+		Result communityResult `json:"result"`
+	} `json:"community_results"`
+
+	EditControl editControl `json:"edit_control"`
+
+	Legacy struct {
+		ConversationIDStr string   `json:"conversation_id_str"`
+		CreatedAt         string   `json:"created_at"`
+		FavoriteCount     int      `json:"favorite_count"`
+		FullText          string   `json:"full_text"`
+		Entities          entities `json:"entities"`
+		DisplayTextRange  []int    `json:"display_text_range"`
+		ExtendedEntities  struct {
+			Media []Media `json:"media"`
+		} `json:"extended_entities"`
+		InReplyToStatusIDStr  string `json:"in_reply_to_status_id_str"`
+		Place                 Place  `json:"place"`
+		ReplyCount            int    `json:"reply_count"`
+		RetweetCount          int    `json:"retweet_count"`
+		RetweetedStatusIDStr  string `json:"retweeted_status_id_str"`
+		RetweetedStatusResult struct {
+			Result *tweetResult `json:"result"`
+		} `json:"retweeted_status_result"`
+		QuoteCount                int       `json:"quote_count"`
+		BookmarkCount             int       `json:"bookmark_count"`
+		Lang                      string    `json:"lang"`
+		IsQuoteStatus             bool      `json:"is_quote_status"`
+		PossiblySensitive         bool      `json:"possibly_sensitive"`
+		PossiblySensitiveEditable bool      `json:"possibly_sensitive_editable"`
+		QuotedStatusIDStr         string    `json:"quoted_status_id_str"`
+		Time                      time.Time `json:"time"`
+		UserIDStr                 string    `json:"user_id_str"`
+	} `json:"legacy"`
+	HasModeratedReplies bool `json:"hasModeratedReplies"`
+
+	QuotedStatusResult struct {
+		Result *tweetResult `json:"result"`
+	} `json:"quoted_status_result"`
+
+	// TweetTombstone of the deleted tweet
+	Tombstone struct {
+		Text struct {
+			Text string `json:"text"`
+		} `json:"text"`
+	} `json:"tombstone"`
+
+	// TweetWithVisibilityResults wraps the actual tweet
+	Tweet *tweetResult `json:"tweet"`
+}
+
 type Card struct {
-	RestId string
+	RestId string `json:"rest_id"`
 	Legacy struct {
+		Name          string `json:"name"`
+		URL           string `json:"url"`
 		BindingValues []struct {
 			Key   string `json:"key"`
 			Value struct {
-				StringValue string `json:"string_value"`
-				Type        string `json:"type"`
+				StringValue  string `json:"string_value"`
+				BooleanValue bool   `json:"boolean_value"`
+				ImageValue   struct {
+					URL    string `json:"url"`
+					Width  int    `json:"width"`
+					Height int    `json:"height"`
+					Alt    string `json:"alt"`
+				} `json:"image_value"`
+				UserValue struct {
+					IDStr string `json:"id_str"`
+				} `json:"user_value"`
+				Type string `json:"type"`
 			} `json:"value"`
 		} `json:"binding_values"`
+		UserRefsResults []struct {
+			Result UserResult `json:"result"`
+		} `json:"user_refs_results"`
 	} `json:"legacy"`
 }
 
+// bindingValue returns string value of the card binding by key
+func (c Card) bindingValue(key string) string {
+	for _, v := range c.Legacy.BindingValues {
+		if v.Key == key {
+			return v.Value.StringValue
+		}
+	}
+	return ""
+}
+
 type TweetThreadTree struct {
 	Store map[string]Tweet
 	Root  NodeTweet
@@ -221,16 +286,10 @@ type contents struct {
 
 func (s *Scraper) GetTweetAndRepliesRecursive(id string) ([]Tweet, map[string]Profile, error) {
 	var tweets []Tweet
-	var users map[string]Profile
+	users := make(map[string]Profile)
 	var tlContents []contents
 
-	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/BoHLKeBvibdYDiJON1oqTg/TweetDetail?variables=%7B%22focalTweetId%22%3A%22"+id+"%22%2C%22with_rux_injections%22%3Afalse%2C%22includePromotedContent%22%3Afalse%2C%22withCommunity%22%3Afalse%2C%22withQuickPromoteEligibilityTweetFields%22%3Afalse%2C%22withBirdwatchNotes%22%3Afalse%2C%22withSuperFollowsUserFields%22%3Afalse%2C%22withDownvotePerspective%22%3Afalse%2C%22withReactionsMetadata%22%3Afalse%2C%22withReactionsPerspective%22%3Afalse%2C%22withSuperFollowsTweetFields%22%3Afalse%2C%22withVoice%22%3Afalse%2C%22withV2Timeline%22%3Atrue%7D%26features%3D%7B%22responsive_web_twitter_blue_verified_badge_is_enabled%22%3Atrue%2C%22verified_phone_label_enabled%22%3Afalse%2C%22responsive_web_graphql_timeline_navigation_enabled%22%3Atrue%2C%22unified_cards_ad_metadata_container_dynamic_card_content_query_enabled%22%3Atrue%2C%22tweetypie_unmention_optimization_enabled%22%3Atrue%2C%22responsive_web_uc_gql_enabled%22%3Atrue%2C%22vibe_api_enabled%22%3Atrue%2C%22responsive_web_edit_tweet_api_enabled%22%3Atrue%2C%22graphql_is_translatable_rweb_tweet_is_translatable_enabled%22%3Atrue%2C%22standardized_nudges_misinfo%22%3Atrue%2C%22tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled%22%3Afalse%2C%22interactive_text_enabled%22%3Atrue%2C%22responsive_web_text_conversations_enabled%22%3Afalse%2C%22responsive_web_enhance_cards_enabled%22%3Atrue%7D")
-	if err != nil {
-		return tweets, users, err
-	}
-
-	var firstjsn timelinerecursive
-	err = s.RequestAPI(req, &firstjsn)
+	firstjsn, err := s.getTweetDetail(id, "")
 	if err != nil {
 		return tweets, users, err
 	}
@@ -245,24 +304,21 @@ func (s *Scraper) GetTweetAndRepliesRecursive(id string) ([]Tweet, map[string]Pr
 		return tweets, users, fmt.Errorf("no Instruction")
 	}
 
-	cursorTop := ""
+	topjsn, topint, lastTop := firstjsn, 0, ""
 
 	for {
-		for _, entry := range firstjsn.Data.ThreadedConvo.Instructions[0].Entries {
+		cursorTop := ""
+		for _, entry := range topjsn.Data.ThreadedConvo.Instructions[topint].Entries {
 			if strings.HasPrefix(entry.EntryId, "cursor-top") {
 				cursorTop = entry.Content.ItemContent.Value
 				break
 			}
 		}
 
-		if cursorTop != "" {
-			req, err = s.newRequest("GET", "https://twitter.com/i/api/graphql/BoHLKeBvibdYDiJON1oqTg/TweetDetail?variables%3D%7B%22focalTweetId%22%3A%22"+id+"%22%2C%22cursor%22%3A%22"+cursorTop+"%22%2C%22referrer%22%3A%22messages%22%2C%22with_rux_injections%22%3Afalse%2C%22includePromotedContent%22%3Afa%3Bse%2C%22withCommunity%22%3Afalse%2C%22withQuickPromoteEligibilityTweetFields%22%3Afalse%2C%22withBirdwatchNotes%22%3Afalse%2C%22withSuperFollowsUserFields%22%3Afalse%2C%22withDownvotePerspective%22%3Afalse%2C%22withReactionsMetadata%22%3Afalse%2C%22withReactionsPerspective%22%3Afalse%2C%22withSuperFollowsTweetFields%22%3Afalse%2C%22withVoice%22%3Atrue%2C%22withV2Timeline%22%3Atrue%7D%26features%3D%7B%22responsive_web_twitter_blue_verified_badge_is_enabled%22%3Atrue%2C%22verified_phone_label_enabled%22%3Afalse%2C%22responsive_web_graphql_timeline_navigation_enabled%22%3Atrue%2C%22unified_cards_ad_metadata_container_dynamic_card_content_query_enabled%22%3Atrue%2C%22tweetypie_unmention_optimization_enabled%22%3Atrue%2C%22responsive_web_uc_gql_enabled%22%3Atrue%2C%22vibe_api_enabled%22%3Atrue%2C%22responsive_web_edit_tweet_api_enabled%22%3Atrue%2C%22graphql_is_translatable_rweb_tweet_is_translatable_enabled%22%3Atrue%2C%22standardized_nudges_misinfo%22%3Atrue%2C%22tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled%22%3Afalse%2C%22interactive_text_enabled%22%3Atrue%2C%22responsive_web_text_conversations_enabled%22%3Afalse%2C%22responsive_web_enhance_cards_enabled%22%3Atrue%7D")
-			if err != nil {
-				return tweets, users, err
-			}
-
-			var frontmatterjsn timelinerecursive
-			err = s.RequestAPI(req, &frontmatterjsn)
+		// stop when there are no more pages or the cursor repeats
+		if cursorTop != "" && cursorTop != lastTop {
+			lastTop = cursorTop
+			frontmatterjsn, err := s.getTweetDetail(id, cursorTop)
 			if err != nil {
 				return tweets, users, err
 			}
@@ -306,6 +362,7 @@ func (s *Scraper) GetTweetAndRepliesRecursive(id string) ([]Tweet, map[string]Pr
 			}
 
 			tlContents = append(inttwts, tlContents...)
+			topjsn, topint = frontmatterjsn, convoint
 		} else {
 			break
 		}
@@ -339,26 +396,22 @@ func (s *Scraper) GetTweetAndRepliesRecursive(id string) ([]Tweet, map[string]Pr
 		}
 	}
 
-	tlContents = append(aaatwts, tlContents...)
+	tlContents = append(tlContents, aaatwts...)
 
-	cursorBottom := ""
+	bottomjsn, bottomint, lastBottom := firstjsn, cvi, ""
 
 	for {
-		for _, entry := range firstjsn.Data.ThreadedConvo.Instructions[cvi].Entries {
+		cursorBottom := ""
+		for _, entry := range bottomjsn.Data.ThreadedConvo.Instructions[bottomint].Entries {
 			if strings.HasPrefix(entry.EntryId, "cursor-bottom") {
 				cursorBottom = entry.Content.ItemContent.Value
 				break
 			}
 		}
 
-		if cursorBottom != "" {
-			req, err = s.newRequest("GET", "https://twitter.com/i/api/graphql/BoHLKeBvibdYDiJON1oqTg/TweetDetail?variables%3D%7B%22focalTweetId%22%3A%22"+id+"%22%2C%22cursor%22%3A%22"+cursorBottom+"%22%2C%22referrer%22%3A%22messages%22%2C%22with_rux_injections%22%3Afalse%2C%22includePromotedContent%22%3Afa%3Bse%2C%22withCommunity%22%3Afalse%2C%22withQuickPromoteEligibilityTweetFields%22%3Afalse%2C%22withBirdwatchNotes%22%3Afalse%2C%22withSuperFollowsUserFields%22%3Afalse%2C%22withDownvotePerspective%22%3Afalse%2C%22withReactionsMetadata%22%3Afalse%2C%22withReactionsPerspective%22%3Afalse%2C%22withSuperFollowsTweetFields%22%3Afalse%2C%22withVoice%22%3Atrue%2C%22withV2Timeline%22%3Atrue%7D%26features%3D%7B%22responsive_web_twitter_blue_verified_badge_is_enabled%22%3Atrue%2C%22verified_phone_label_enabled%22%3Afalse%2C%22responsive_web_graphql_timeline_navigation_enabled%22%3Atrue%2C%22unified_cards_ad_metadata_container_dynamic_card_content_query_enabled%22%3Atrue%2C%22tweetypie_unmention_optimization_enabled%22%3Atrue%2C%22responsive_web_uc_gql_enabled%22%3Atrue%2C%22vibe_api_enabled%22%3Atrue%2C%22responsive_web_edit_tweet_api_enabled%22%3Atrue%2C%22graphql_is_translatable_rweb_tweet_is_translatable_enabled%22%3Atrue%2C%22standardized_nudges_misinfo%22%3Atrue%2C%22tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled%22%3Afalse%2C%22interactive_text_enabled%22%3Atrue%2C%22responsive_web_text_conversations_enabled%22%3Afalse%2C%22responsive_web_enhance_cards_enabled%22%3Atrue%7D")
-			if err != nil {
-				return tweets, users, err
-			}
-
-			var backmatterjsn timelinerecursive
-			err = s.RequestAPI(req, &backmatterjsn)
+		if cursorBottom != "" && cursorBottom != lastBottom {
+			lastBottom = cursorBottom
+			backmatterjsn, err := s.getTweetDetail(id, cursorBottom)
 			if err != nil {
 				return tweets, users, err
 			}
@@ -401,7 +454,8 @@ func (s *Scraper) GetTweetAndRepliesRecursive(id string) ([]Tweet, map[string]Pr
 				}
 			}
 
-			tlContents = append(inttwts, tlContents...)
+			tlContents = append(tlContents, inttwts...)
+			bottomjsn, bottomint = backmatterjsn, convoint
 		} else {
 			break
 		}
@@ -412,7 +466,7 @@ func (s *Scraper) GetTweetAndRepliesRecursive(id string) ([]Tweet, map[string]Pr
 			// find tweet id by parsing the entry ID
 			splits := strings.Split(possibleTweet.Entry, "-")
 			// get the last part as int
-			if len(splits) < 4 {
+			if len(splits) < 5 {
 				continue
 			}
 			tweetId := splits[4]
@@ -429,6 +483,9 @@ func (s *Scraper) GetTweetAndRepliesRecursive(id string) ([]Tweet, map[string]Pr
 		if err != nil {
 			continue
 		}
+		if s.quoteDepth > 0 {
+			limitQuoteDepth(&twt, s.quoteDepth)
+		}
 		tweets = append(tweets, twt)
 
 		// find users
@@ -441,177 +498,362 @@ func (s *Scraper) GetTweetAndRepliesRecursive(id string) ([]Tweet, map[string]Pr
 	return tweets, users, nil
 }
 
-// FetchTweets gets tweets for a given user, via the Twitter frontend API.
-func (s *Scraper) FetchTweets(user string) ([]Tweet, error) {
-	var tlContents []contents
-	var tweets []Tweet
-
-	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/BoHLKeBvibdYDiJON1oqTg/TweetDetail?variables=%7B%22focalTweetId%22%3A%22"+id+"%22%2C%22with_rux_injections%22%3Afalse%2C%22includePromotedContent%22%3Afalse%2C%22withCommunity%22%3Afalse%2C%22withQuickPromoteEligibilityTweetFields%22%3Afalse%2C%22withBirdwatchNotes%22%3Afalse%2C%22withSuperFollowsUserFields%22%3Afalse%2C%22withDownvotePerspective%22%3Afalse%2C%22withReactionsMetadata%22%3Afalse%2C%22withReactionsPerspective%22%3Afalse%2C%22withSuperFollowsTweetFields%22%3Afalse%2C%22withVoice%22%3Afalse%2C%22withV2Timeline%22%3Atrue%7D%26features%3D%7B%22responsive_web_twitter_blue_verified_badge_is_enabled%22%3Atrue%2C%22verified_phone_label_enabled%22%3Afalse%2C%22responsive_web_graphql_timeline_navigation_enabled%22%3Atrue%2C%22unified_cards_ad_metadata_container_dynamic_card_content_query_enabled%22%3Atrue%2C%22tweetypie_unmention_optimization_enabled%22%3Atrue%2C%22responsive_web_uc_gql_enabled%22%3Atrue%2C%22vibe_api_enabled%22%3Atrue%2C%22responsive_web_edit_tweet_api_enabled%22%3Atrue%2C%22graphql_is_translatable_rweb_tweet_is_translatable_enabled%22%3Atrue%2C%22standardized_nudges_misinfo%22%3Atrue%2C%22tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled%22%3Afalse%2C%22interactive_text_enabled%22%3Atrue%2C%22responsive_web_text_conversations_enabled%22%3Afalse%2C%22responsive_web_enhance_cards_enabled%22%3Atrue%7D")
+// GetTweet returns the tweet by ID with the quoted tweets, via the Twitter frontend GraphQL API.
+func (s *Scraper) GetTweet(id string) (*Tweet, error) {
+	jsn, err := s.getTweetDetail(id, "")
 	if err != nil {
-		return tweets, err
+		return nil, err
 	}
 
-	var firstjsn timelinerecursive
-	err = s.RequestAPI(req, &firstjsn)
-	if err != nil {
-		return tweets, err
+	for _, instruction := range jsn.Data.ThreadedConvo.Instructions {
+		for _, entry := range instruction.Entries {
+			for _, content := range entry.itemContents() {
+				tweet, err := ItemContentToTweet(content)
+				if err != nil {
+					continue
+				}
+				if tweet.ID == id || stringInSlice(id, tweet.EditIds) {
+					return s.prepareTweets([]*Tweet{&tweet})[0], nil
+				}
+			}
+		}
 	}
 
-	if len(firstjsn.Errors) > 0 {
-		if firstjsn.Errors[0].Code != 37 {
-			return tweets, fmt.Errorf("%s", firstjsn.Errors[0].Message)
-		}
+	if len(jsn.Errors) > 0 {
+		return nil, jsn.Errors[0]
 	}
+	return nil, ErrTweetNotFound
+}
 
-	if len(firstjsn.Data.ThreadedConvo.Instructions) < 1 {
-		return tweets, fmt.Errorf("no Instruction")
+// getTweetDetail gets conversation of the tweet, via the Twitter frontend GraphQL API
+func (s *Scraper) getTweetDetail(id string, cursor string) (*timelinerecursive, error) {
+	variables := map[string]interface{}{
+		"focalTweetId":                           id,
+		"with_rux_injections":                    false,
+		"includePromotedContent":                 false,
+		"withCommunity":                          true,
+		"withQuickPromoteEligibilityTweetFields": false,
+		"withBirdwatchNotes":                     true,
+		"withVoice":                              true,
+		"withV2Timeline":                         true,
+	}
+	if cursor != "" {
+		variables["cursor"] = cursor
+		variables["referrer"] = "tweet"
 	}
 
-	var aaatwts []contents
+	req, err := s.newGraphQLRequest("BoHLKeBvibdYDiJON1oqTg/TweetDetail", variables)
+	if err != nil {
+		return nil, err
+	}
 
-	cvi := 0
+	var jsn timelinerecursive
+	err = s.RequestAPI(req, &jsn)
+	if err != nil {
+		return nil, err
+	}
+	return &jsn, nil
+}
 
-	for i, inst := range firstjsn.Data.ThreadedConvo.Instructions {
-		if inst.Type == "TimelineAddEntries" {
-			cvi = i
-			break
-		}
+// userTimeline JSON object returned by the user timelines
+type userTimeline struct {
+	Errors []Err `json:"errors"`
+	Data   struct {
+		User struct {
+			Result struct {
+				TypeName   string `json:"__typename"`
+				TimelineV2 struct {
+					Timeline timelineV2 `json:"timeline"`
+				} `json:"timeline_v2"`
+				// user lists use timeline instead of timeline_v2
+				Timeline struct {
+					Timeline timelineV2 `json:"timeline"`
+				} `json:"timeline"`
+			} `json:"result"`
+		} `json:"user"`
+	} `json:"data"`
+}
+
+// GetTweets returns channel with tweets for a given user.
+func (s *Scraper) GetTweets(ctx context.Context, user string, maxTweetsNbr int) <-chan *TweetResult {
+	return getTweetTimeline(ctx, user, maxTweetsNbr, s.FetchTweets)
+}
+
+// Deprecated: GetTweets wrapper for default Scraper
+func GetTweets(ctx context.Context, user string, maxTweetsNbr int) <-chan *TweetResult {
+	return defaultScraper.GetTweets(ctx, user, maxTweetsNbr)
+}
+
+// FetchTweets gets tweets for a given user, via the Twitter frontend API.
+// Replies are included if enabled by WithReplies.
+func (s *Scraper) FetchTweets(user string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	if s.includeReplies {
+		return s.FetchTweetsAndReplies(user, maxTweetsNbr, cursor)
 	}
+	tweets, nextCursor, err := s.fetchUserTimeline("V1ze5q3ijDS1VeLwLY0m7g/UserTweets", user, maxTweetsNbr, cursor)
+	return s.prepareTweets(tweets), nextCursor, err
+}
 
-	for _, entry := range firstjsn.Data.ThreadedConvo.Instructions[cvi].Entries {
-		if strings.HasPrefix(entry.EntryId, "conversationthread") {
+// GetMediaTweets returns channel with tweets with photos and videos for a given user.
+func (s *Scraper) GetMediaTweets(ctx context.Context, user string, maxTweetsNbr int) <-chan *TweetResult {
+	return getTweetTimeline(ctx, user, maxTweetsNbr, s.FetchMediaTweets)
+}
 
-			for _, item := range entry.Content.Items {
-				aaatwts = append(aaatwts, contents{
-					ItemContent: item.Item.ItemContent,
-					Entry:       item.EntryId,
-				})
-			}
-		} else {
-			aaatwts = append(aaatwts, contents{
-				ItemContent: entry.Content.ItemContent,
-				Entry:       entry.EntryId,
-			})
-		}
+// Deprecated: GetMediaTweets wrapper for default Scraper
+func GetMediaTweets(ctx context.Context, user string, maxTweetsNbr int) <-chan *TweetResult {
+	return defaultScraper.GetMediaTweets(ctx, user, maxTweetsNbr)
+}
+
+// FetchMediaTweets gets tweets with photos and videos for a given user, via the Twitter frontend API.
+func (s *Scraper) FetchMediaTweets(user string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	tweets, nextCursor, err := s.fetchUserTimeline("2tLOJWwGuCTytDrGBg8VwQ/UserMedia", user, maxTweetsNbr, cursor)
+	return s.prepareTweets(tweets), nextCursor, err
+}
+
+// GetLikedTweets returns channel with tweets liked by a given user.
+// Likes are visible only to an authenticated session, see WithCookie.
+func (s *Scraper) GetLikedTweets(ctx context.Context, user string, maxTweetsNbr int) <-chan *TweetResult {
+	return getTweetTimeline(ctx, user, maxTweetsNbr, s.FetchLikedTweets)
+}
+
+// Deprecated: GetLikedTweets wrapper for default Scraper
+func GetLikedTweets(ctx context.Context, user string, maxTweetsNbr int) <-chan *TweetResult {
+	return defaultScraper.GetLikedTweets(ctx, user, maxTweetsNbr)
+}
+
+// FetchLikedTweets gets tweets liked by a given user, via the Twitter frontend API.
+// Returns ErrAuthRequired without authenticated session and ErrLikesPrivate if likes are hidden.
+func (s *Scraper) FetchLikedTweets(user string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	if !s.IsLoggedIn() {
+		return nil, "", ErrAuthRequired
 	}
 
-	tlContents = append(aaatwts, tlContents...)
+	tweets, nextCursor, err := s.fetchUserTimeline("lVf2NuhLoYVrpN4nO7uw0Q/Likes", user, maxTweetsNbr, cursor)
+	var apiErr Err
+	if errors.As(err, &apiErr) && apiErr.Code == 37 && apiErr.Kind == "Permissions" {
+		return nil, "", fmt.Errorf("%w: %s", ErrLikesPrivate, apiErr.Message)
+	}
+	return s.prepareTweets(tweets), nextCursor, err
+}
+
+// GetTweetsAndReplies returns channel with tweets and replies for a given user.
+func (s *Scraper) GetTweetsAndReplies(ctx context.Context, user string, maxTweetsNbr int) <-chan *TweetResult {
+	return getTweetTimeline(ctx, user, maxTweetsNbr, s.FetchTweetsAndReplies)
+}
+
+// Deprecated: GetTweetsAndReplies wrapper for default Scraper
+func GetTweetsAndReplies(ctx context.Context, user string, maxTweetsNbr int) <-chan *TweetResult {
+	return defaultScraper.GetTweetsAndReplies(ctx, user, maxTweetsNbr)
+}
+
+// FetchTweetsAndReplies gets tweets and replies for a given user, via the Twitter frontend API.
+// Tweets of other users, which the timeline shows as context of the conversation, are skipped,
+// pages made only of them are passed while the cursor advances.
+func (s *Scraper) FetchTweetsAndReplies(user string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	userID, err := s.GetUserIDByScreenName(user)
+	if err != nil {
+		return nil, "", err
+	}
 
 	for {
-		for _, entry := range firstjsn.Data.ThreadedConvo.Instructions[cvi].Entries {
-			if strings.HasPrefix(entry.EntryId, "cursor-bottom") {
-				cursorBottom = entry.Content.ItemContent.Value
-				break
-			}
+		tweets, nextCursor, err := s.fetchUserTimelineByID("E4wA5vo2sjVyvpliUffSCw/UserTweetsAndReplies", userID, maxTweetsNbr, cursor)
+		if err != nil {
+			return nil, "", err
 		}
 
-		if cursorBottom != "" {
-			req, err = s.newRequest("GET", "https://twitter.com/i/api/graphql/BoHLKeBvibdYDiJON1oqTg/TweetDetail?variables%3D%7B%22focalTweetId%22%3A%22"+id+"%22%2C%22cursor%22%3A%22"+cursorBottom+"%22%2C%22referrer%22%3A%22messages%22%2C%22with_rux_injections%22%3Afalse%2C%22includePromotedContent%22%3Afa%3Bse%2C%22withCommunity%22%3Afalse%2C%22withQuickPromoteEligibilityTweetFields%22%3Afalse%2C%22withBirdwatchNotes%22%3Afalse%2C%22withSuperFollowsUserFields%22%3Afalse%2C%22withDownvotePerspective%22%3Afalse%2C%22withReactionsMetadata%22%3Afalse%2C%22withReactionsPerspective%22%3Afalse%2C%22withSuperFollowsTweetFields%22%3Afalse%2C%22withVoice%22%3Atrue%2C%22withV2Timeline%22%3Atrue%7D%26features%3D%7B%22responsive_web_twitter_blue_verified_badge_is_enabled%22%3Atrue%2C%22verified_phone_label_enabled%22%3Afalse%2C%22responsive_web_graphql_timeline_navigation_enabled%22%3Atrue%2C%22unified_cards_ad_metadata_container_dynamic_card_content_query_enabled%22%3Atrue%2C%22tweetypie_unmention_optimization_enabled%22%3Atrue%2C%22responsive_web_uc_gql_enabled%22%3Atrue%2C%22vibe_api_enabled%22%3Atrue%2C%22responsive_web_edit_tweet_api_enabled%22%3Atrue%2C%22graphql_is_translatable_rweb_tweet_is_translatable_enabled%22%3Atrue%2C%22standardized_nudges_misinfo%22%3Atrue%2C%22tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled%22%3Afalse%2C%22interactive_text_enabled%22%3Atrue%2C%22responsive_web_text_conversations_enabled%22%3Afalse%2C%22responsive_web_enhance_cards_enabled%22%3Atrue%7D")
-			if err != nil {
-				return tweets, users, err
-			}
-
-			var backmatterjsn timelinerecursive
-			err = s.RequestAPI(req, &backmatterjsn)
-			if err != nil {
-				return tweets, users, err
+		var ownTweets []*Tweet
+		for _, tweet := range tweets {
+			if tweet.UserID == userID {
+				ownTweets = append(ownTweets, tweet)
 			}
+		}
+		if len(ownTweets) > 0 || len(tweets) == 0 || nextCursor == "" || nextCursor == cursor {
+			return s.prepareTweets(ownTweets), nextCursor, nil
+		}
+		cursor = nextCursor
+	}
+}
 
-			if len(backmatterjsn.Errors) > 0 {
-				if backmatterjsn.Errors[0].Code != 37 {
-					return tweets, users, fmt.Errorf("%s", backmatterjsn.Errors[0].Message)
-				}
-			}
+// fetchUserTimeline gets tweets of GraphQL user timeline operation for a given user
+func (s *Scraper) fetchUserTimeline(operation string, user string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	userID, err := s.GetUserIDByScreenName(user)
+	if err != nil {
+		return nil, "", err
+	}
+	return s.fetchUserTimelineByID(operation, userID, maxTweetsNbr, cursor)
+}
 
-			if len(backmatterjsn.Data.ThreadedConvo.Instructions) < 1 {
-				return tweets, users, fmt.Errorf("no instructions")
-			}
+// fetchUserTimelineByID gets tweets of GraphQL user timeline operation for a given user ID
+func (s *Scraper) fetchUserTimelineByID(operation string, userID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	if maxTweetsNbr > 50 {
+		maxTweetsNbr = 50
+	}
 
-			convoint := 0
+	variables := map[string]interface{}{
+		"userId":                                 userID,
+		"count":                                  maxTweetsNbr,
+		"includePromotedContent":                 false,
+		"withQuickPromoteEligibilityTweetFields": false,
+		"withVoice":                              true,
+		"withV2Timeline":                         true,
+	}
+	if cursor != "" {
+		variables["cursor"] = cursor
+	}
 
-			for i, inst := range backmatterjsn.Data.ThreadedConvo.Instructions {
-				if inst.Type == "TimelineAddEntries" {
-					convoint = i
-					break
-				}
-			}
+	req, err := s.newGraphQLRequest(operation, variables)
+	if err != nil {
+		return nil, "", err
+	}
 
-			var inttwts []contents
+	var jsn userTimeline
+	err = s.RequestAPI(req, &jsn)
+	if err != nil {
+		return nil, "", err
+	}
 
-			for _, entry := range backmatterjsn.Data.ThreadedConvo.Instructions[convoint].Entries {
-				if strings.HasPrefix(entry.EntryId, "conversationthread") {
+	timeline := &jsn.Data.User.Result.TimelineV2.Timeline
+	if len(jsn.Errors) > 0 && len(timeline.Instructions) == 0 {
+		return nil, "", jsn.Errors[0]
+	}
 
-					for _, item := range entry.Content.Items {
-						inttwts = append(inttwts, contents{
-							ItemContent: item.Item.ItemContent,
-							Entry:       item.EntryId,
-						})
-					}
-				} else {
-					inttwts = append(inttwts, contents{
-						ItemContent: entry.Content.ItemContent,
-						Entry:       entry.EntryId,
-					})
-				}
-			}
+	tweets, nextCursor := timeline.parseTweets()
+	return tweets, nextCursor, nil
+}
 
-			tlContents = append(inttwts, tlContents...)
-		} else {
-			break
+// prepareTweets replaces retweets by the original tweets if enabled by WithUnwrapRetweets
+// and drops quoted tweets nested deeper than set by WithQuoteDepth
+func (s *Scraper) prepareTweets(tweets []*Tweet) []*Tweet {
+	prepared := make([]*Tweet, 0, len(tweets))
+	for _, tweet := range tweets {
+		if s.unwrapRetweets && tweet.RetweetedStatus != nil {
+			tweet = tweet.RetweetedStatus
+		}
+		if s.quoteDepth > 0 {
+			limitQuoteDepth(tweet, s.quoteDepth)
 		}
+		prepared = append(prepared, tweet)
 	}
+	return prepared
 }
 
+// ItemContentToTweet parses tweet of the timeline item, the quote depth limit is applied by the Scraper callers.
 func ItemContentToTweet(content itemcontent) (Tweet, error) {
-	if content.TweetResults.Result.RestId == "" {
-		return Tweet{}, fmt.Errorf("no rest ID")
+	return parseTweetResult(content.TweetResults.Result)
+}
+
+func parseTweetResult(result tweetResult) (Tweet, error) {
+	limited := result.LimitedActionResults
+	if result.TypeName == "TweetWithVisibilityResults" && result.Tweet != nil {
+		result = *result.Tweet
+	}
+	if len(limited.LimitedActions) == 0 {
+		limited = result.LimitedActionResults
 	}
 
-	var hashtags []string
-	for _, ht := range content.TweetResults.Result.Legacy.Entities.Hashtags {
-		hashtags = append(hashtags, ht.Text)
+	if result.RestId == "" {
+		return Tweet{}, fmt.Errorf("no rest ID")
 	}
 
-	timestamp, err := strconv.ParseInt(content.TweetResults.Result.Legacy.CreatedAt, 10, 64)
+	tm, err := time.Parse(time.RubyDate, result.Legacy.CreatedAt)
 	if err != nil {
 		return Tweet{}, err
 	}
 
-	tweetid := content.TweetResults.Result.EditControl.InitialTweetId
+	tweetid := result.EditControl.InitialTweetId
 	if tweetid == "" {
-		tweetid = content.TweetResults.Result.RestId
+		tweetid = result.RestId
 	}
 
-	var mention map[string]string
+	username := result.Core.UserResults.Result.Legacy.ScreenName
 
-	for _, m := range content.TweetResults.Result.Legacy.Entities.UserMentions {
-		mention[m.IdStr] = m.Name
+	tweet := Tweet{
+		ID:                  tweetid,
+		ReplyingTo:          result.Legacy.InReplyToStatusIDStr,
+		ConversationID:      result.Legacy.ConversationIDStr,
+		CommunityID:         result.CommunityResults.Result.IDStr,
+		QuoteRetweetId:      result.Legacy.QuotedStatusIDStr,
+		IsReply:             result.Legacy.InReplyToStatusIDStr != "",
+		IsRetweet:           result.Legacy.RetweetedStatusIDStr != "" || result.Legacy.RetweetedStatusResult.Result != nil,
+		RetweetedStatusID:   result.Legacy.RetweetedStatusIDStr,
+		PermanentURL:        fmt.Sprintf("https://twitter.com/%s/status/%s", username, result.RestId),
+		Media:               result.Legacy.ExtendedEntities.Media,
+		Card:                result.Card,
+		Text:                result.Legacy.FullText,
+		TimeParsed:          tm.UTC(),
+		Timestamp:           tm.Unix(),
+		UserID:              result.Legacy.UserIDStr,
+		Username:            username,
+		SensitiveContent:    result.Legacy.PossiblySensitive,
+		IsSensitiveEditable: result.Legacy.PossiblySensitiveEditable,
+		Lang:                result.Legacy.Lang,
+		Bookmarks:           result.Legacy.BookmarkCount,
+		Likes:               result.Legacy.FavoriteCount,
+		Retweets:            result.Legacy.RetweetCount,
+		QuoteRetweets:       result.Legacy.QuoteCount,
+		Replies:             result.Legacy.ReplyCount,
+		IsTombstone:         false,
 	}
 
-	tweet := Tweet{
-		ID:               tweetid,
-		EditIds:          content.TweetResults.Result.EditControl.EditIds,
-		Hashtags:         hashtags,
-		ReplyingTo:       content.TweetResults.Result.Legacy.InReplyToStatusIDStr,
-		QuoteRetweetId:   content.TweetResults.Result.Legacy.QuotedStatusIDStr,
-		IsReply:          content.TweetResults.Result.Legacy.InReplyToStatusIDStr == "",
-		IsRetweet:        false,
-		PermanentURL:     "https://twitter.com/i/status" + content.TweetResults.Result.RestId,
-		Media:            content.TweetResults.Result.Legacy.ExtendedEntities.Media,
-		Card:             content.TweetResults.Result.Card,
-		Mentions:         mention,
-		Text:             content.TweetResults.Result.Legacy.FullText,
-		TimeParsed:       time.Now(),
-		Timestamp:        timestamp,
-		UserID:           content.TweetResults.Result.Legacy.UserIDStr,
-		Username:         content.TweetResults.Result.Core.UserResults.Result.Legacy.Name,
-		SensitiveContent: content.TweetResults.Result.Legacy.PossiblySensitive,
-		Likes:            content.TweetResults.Result.Legacy.FavoriteCount,
-		Retweets:         content.TweetResults.Result.Legacy.RetweetCount,
-		QuoteRetweets:    content.TweetResults.Result.Legacy.QuoteCount,
-		Replies:          content.TweetResults.Result.Legacy.ReplyCount,
-		IsTombstone:      false,
+	parseEditControl(&tweet, result.EditControl)
+
+	if result.Legacy.RetweetedStatusResult.Result != nil {
+		retweeted, err := parseTweetResult(*result.Legacy.RetweetedStatusResult.Result)
+		if err == nil {
+			tweet.RetweetedStatus = &retweeted
+			tweet.RetweetedStatusID = retweeted.ID
+		}
+	}
+
+	tweet.QuotedStatus = parseQuotedStatus(result)
+	tweet.IsQuoted = result.Legacy.IsQuoteStatus || tweet.QuoteRetweetId != "" || tweet.QuotedStatus != nil
+	tweet.Views, _ = strconv.Atoi(result.Views.Count)
+	tweet.ViewsState = result.Views.State
+	tweet.Source, tweet.SourceURL = parseSource(result.Source)
+	for _, action := range limited.LimitedActions {
+		tweet.LimitedActions = append(tweet.LimitedActions, action.Action)
+	}
+
+	parseEntities(&tweet, result.Legacy.Entities)
+	tweet.Segments = parseSegments(result.Legacy.FullText, result.Legacy.Entities, result.Legacy.DisplayTextRange)
+	tweet.ExpandedText = expandText(result.Legacy.FullText, result.Legacy.Entities, result.Legacy.DisplayTextRange)
+	parseMedia(&tweet, tweet.Media)
+	tweet.CommunityNote = parseBirdwatchPivot(result.BirdwatchPivot)
+	tweet.Poll = parsePoll(result.Card)
+
+	urls := make(map[string]string)
+	for _, u := range result.Legacy.Entities.URLs {
+		urls[u.URL] = u.ExpandedURL
+	}
+	tweet.LinkPreview = parseLinkPreview(result.Card, urls)
+
+	// cards of spaces and live videos are named like "3691233323:audiospace"
+	switch {
+	case strings.HasSuffix(result.Card.Legacy.Name, ":audiospace"):
+		tweet.SpaceID = result.Card.bindingValue("id")
+	case strings.HasSuffix(result.Card.Legacy.Name, ":broadcast"):
+		tweet.BroadcastID = result.Card.bindingValue("broadcast_id")
 	}
 
 	return tweet, nil
 }
+
+// parseSource returns name and URL of the client app from the link like
+// <a href="https://mobile.twitter.com" rel="nofollow">Twitter Web App</a>
+func parseSource(source string) (string, string) {
+	var sourceURL string
+	if i := strings.Index(source, `href="`); i >= 0 {
+		sourceURL = source[i+len(`href="`):]
+		if end := strings.Index(sourceURL, `"`); end >= 0 {
+			sourceURL = sourceURL[:end]
+		}
+	}
+	name := source
+	if i := strings.Index(name, ">"); i >= 0 {
+		name = name[i+1:]
+	}
+	if end := strings.Index(name, "<"); end >= 0 {
+		name = name[:end]
+	}
+	return html.UnescapeString(name), html.UnescapeString(sourceURL)
+}
@@ -2,6 +2,8 @@ package twitterscraper_test
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,12 +12,25 @@ import (
 	twitterscraper "github.com/n0madic/twitter-scraper"
 )
 
+// ignoreUnset compares only the fields which are set in the sample
+var ignoreUnset = cmp.FilterPath(func(p cmp.Path) bool {
+	field, ok := p.Last().(cmp.StructField)
+	if !ok {
+		return false
+	}
+	sample, _ := field.Values()
+	return sample.IsValid() && sample.IsZero()
+}, cmp.Ignore())
+
 var cmpOptions = cmp.Options{
 	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "Likes"),
 	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "Replies"),
 	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "Retweets"),
+	ignoreUnset,
 }
 
+var fixtureCmpOptions = cmp.Options{ignoreUnset}
+
 func TestGetTweets(t *testing.T) {
 	count := 0
 	maxTweetsNbr := 300
@@ -73,10 +88,8 @@ func TestGetTweets(t *testing.T) {
 
 func TestGetTweet(t *testing.T) {
 	sample := twitterscraper.Tweet{
-		HTML:         "That thing you didn’t Tweet but wanted to but didn’t but got so close but then were like nah. <br><br>We have a place for that now—Fleets! <br><br>Rolling out to everyone starting today. <br><a href=\"https://t.co/auQAHXZMfH\"><img src=\"https://pbs.twimg.com/amplify_video_thumb/1328684333599756289/img/cP5KwbIXbGunNSBy.jpg\"/></a>",
 		ID:           "1328684389388185600",
 		PermanentURL: "https://twitter.com/Twitter/status/1328684389388185600",
-		Photos:       []string{"https://pbs.twimg.com/amplify_video_thumb/1328684333599756289/img/cP5KwbIXbGunNSBy.jpg"},
 		Text:         "That thing you didn’t Tweet but wanted to but didn’t but got so close but then were like nah. \n\nWe have a place for that now—Fleets! \n\nRolling out to everyone starting today. https://t.co/auQAHXZMfH",
 		TimeParsed:   time.Date(2020, 11, 17, 13, 0, 18, 0, time.FixedZone("UTC", 0)),
 		Timestamp:    1605618018,
@@ -101,17 +114,16 @@ func TestGetTweet(t *testing.T) {
 
 func TestQuotedAndReply(t *testing.T) {
 	sample := &twitterscraper.Tweet{
-		HTML:         "The Easiest Problem Everyone Gets Wrong <br><br>[new video] --&gt; <a href=\"https://youtu.be/ytfCdqWhmdg\">https://t.co/YdaeDYmPAU</a> <br><a href=\"https://t.co/iKu4Xs6o2V\"><img src=\"https://pbs.twimg.com/media/ESsZa9AXgAIAYnF.jpg\"/></a>",
 		ID:           "1237110546383724547",
 		Likes:        485,
 		PermanentURL: "https://twitter.com/VsauceTwo/status/1237110546383724547",
-		Photos:       []string{"https://pbs.twimg.com/media/ESsZa9AXgAIAYnF.jpg"},
+		Photos:       []twitterscraper.Photo{{URL: "https://pbs.twimg.com/media/ESsZa9AXgAIAYnF.jpg"}},
 		Replies:      12,
 		Retweets:     18,
 		Text:         "The Easiest Problem Everyone Gets Wrong \n\n[new video] --&gt; https://t.co/YdaeDYmPAU https://t.co/iKu4Xs6o2V",
 		TimeParsed:   time.Date(2020, 03, 9, 20, 18, 33, 0, time.FixedZone("UTC", 0)),
 		Timestamp:    1583785113,
-		URLs:         []string{"https://youtu.be/ytfCdqWhmdg"},
+		URLs:         []twitterscraper.URL{{ExpandedURL: "https://youtu.be/ytfCdqWhmdg"}},
 		UserID:       "978944851",
 		Username:     "VsauceTwo",
 	}
@@ -134,15 +146,14 @@ func TestQuotedAndReply(t *testing.T) {
 		if !tweet.IsReply {
 			t.Error("IsReply must be True")
 		}
-		if diff := cmp.Diff(sample, tweet.InReplyToStatus, cmpOptions...); diff != "" {
-			t.Error("Resulting reply does not match the sample", diff)
+		if tweet.ReplyingTo != sample.ID {
+			t.Errorf("Expected reply to %s, got %s", sample.ID, tweet.ReplyingTo)
 		}
 	}
 
 }
 func TestRetweet(t *testing.T) {
 	sample := &twitterscraper.Tweet{
-		HTML:         "We’ve seen an increase in attacks against Asian communities and individuals around the world. It’s important to know that this isn’t new; throughout history, Asians have experienced violence and exclusion. However, their diverse lived experiences have largely been overlooked.",
 		ID:           "1359151057872580612",
 		Likes:        6683,
 		PermanentURL: "https://twitter.com/TwitterTogether/status/1359151057872580612",
@@ -167,3 +178,433 @@ func TestRetweet(t *testing.T) {
 		}
 	}
 }
+
+func TestGetTweetFixture(t *testing.T) {
+	sample := twitterscraper.Tweet{
+		ID:           "1328684389388185600",
+		PermanentURL: "https://twitter.com/Twitter/status/1328684389388185600",
+		Text:         "That thing you didn’t Tweet but wanted to but didn’t but got so close but then were like nah. \n\nWe have a place for that now—Fleets! \n\nRolling out to everyone starting today. https://t.co/auQAHXZMfH",
+		TimeParsed:   time.Date(2020, 11, 17, 13, 0, 18, 0, time.UTC),
+		Timestamp:    1605618018,
+		UserID:       "783214",
+		Username:     "Twitter",
+	}
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	tweet, err := scraper.GetTweet("1328684389388185600")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(sample, *tweet, fixtureCmpOptions...); diff != "" {
+		t.Error("Resulting tweet does not match the sample", diff)
+	}
+	html := "That thing you didn’t Tweet but wanted to but didn’t but got so close but then were like nah. <br><br>We have a place for that now—Fleets! <br><br>Rolling out to everyone starting today."
+	if tweet.HTML() != html {
+		t.Errorf("Unexpected HTML %s", tweet.HTML())
+	}
+	if len(tweet.Photos) != 0 || len(tweet.Videos) != 1 {
+		t.Fatalf("Expected single video, got %d photos and %d videos", len(tweet.Photos), len(tweet.Videos))
+	}
+	video := tweet.Videos[0]
+	if video.ID != "1328684333599756289" ||
+		video.Preview != "https://pbs.twimg.com/amplify_video_thumb/1328684333599756289/img/cP5KwbIXbGunNSBy.jpg" ||
+		video.URL != "https://video.twimg.com/amplify_video/1328684333599756289/vid/960x720/PcL8yv8KhgQ48Qpt.mp4?tag=13" {
+		t.Errorf("Unexpected video %v", video)
+	}
+}
+
+func TestQuotedAndReplyFixture(t *testing.T) {
+	sample := twitterscraper.Tweet{
+		ID:           "1237110546383724547",
+		Likes:        485,
+		PermanentURL: "https://twitter.com/VsauceTwo/status/1237110546383724547",
+		Replies:      12,
+		Retweets:     18,
+		Text:         "The Easiest Problem Everyone Gets Wrong \n\n[new video] --&gt; https://t.co/YdaeDYmPAU https://t.co/iKu4Xs6o2V",
+		TimeParsed:   time.Date(2020, 03, 9, 20, 18, 33, 0, time.UTC),
+		Timestamp:    1583785113,
+		UserID:       "978944851",
+		Username:     "VsauceTwo",
+	}
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	tweet, err := scraper.GetTweet("1237110897597976576")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tweet.IsQuoted || tweet.QuotedStatus == nil {
+		t.Fatal("IsQuoted must be True")
+	}
+	quoted := tweet.QuotedStatus
+	if diff := cmp.Diff(sample, *quoted, fixtureCmpOptions...); diff != "" {
+		t.Error("Resulting quote does not match the sample", diff)
+	}
+	if len(quoted.Photos) != 1 || quoted.Photos[0].URL != "https://pbs.twimg.com/media/ESsZa9AXgAIAYnF.jpg" {
+		t.Errorf("Unexpected photos of quote %v", quoted.Photos)
+	}
+	if len(quoted.URLs) != 1 || quoted.URLs[0].ExpandedURL != "https://youtu.be/ytfCdqWhmdg" {
+		t.Errorf("Unexpected URLs of quote %v", quoted.URLs)
+	}
+
+	tweet, err = scraper.GetTweet("1237111868445134850")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tweet.IsReply {
+		t.Error("IsReply must be True")
+	}
+	if tweet.ReplyingTo != sample.ID || tweet.ConversationID != sample.ID {
+		t.Errorf("Expected reply to %s, got %s in conversation %s", sample.ID, tweet.ReplyingTo, tweet.ConversationID)
+	}
+}
+
+func TestGetRetweetFixture(t *testing.T) {
+	sample := twitterscraper.Tweet{
+		ID:           "1359151057872580612",
+		Likes:        6683,
+		PermanentURL: "https://twitter.com/TwitterTogether/status/1359151057872580612",
+		Replies:      456,
+		Retweets:     1495,
+		Text:         "We’ve seen an increase in attacks against Asian communities and individuals around the world. It’s important to know that this isn’t new; throughout history, Asians have experienced violence and exclusion. However, their diverse lived experiences have largely been overlooked.",
+		TimeParsed:   time.Date(2021, 02, 9, 14, 43, 58, 0, time.UTC),
+		Timestamp:    1612881838,
+		UserID:       "773578328498372608",
+		Username:     "TwitterTogether",
+	}
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	tweet, err := scraper.GetTweet("1362849141248974853")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tweet.IsRetweet || tweet.RetweetedStatus == nil {
+		t.Fatal("IsRetweet must be True")
+	}
+	if diff := cmp.Diff(sample, *tweet.RetweetedStatus, fixtureCmpOptions...); diff != "" {
+		t.Error("Resulting retweet does not match the sample", diff)
+	}
+}
+
+func TestGetTweetsFixture(t *testing.T) {
+	scraper, transport := twitterscraper.NewFixtureScraper()
+	var tweets []twitterscraper.Tweet
+	for tweet := range scraper.GetTweets(context.Background(), "Twitter", 10) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		tweets = append(tweets, tweet.Tweet)
+	}
+
+	expectedIDs := []string{"1711000000000000000", "1712200000000000001", "1712100000000000002", "1712100000000000003", "1711500000000000004"}
+	if len(tweets) != len(expectedIDs) {
+		t.Fatalf("Expected %d tweets, got %d", len(expectedIDs), len(tweets))
+	}
+	for i, id := range expectedIDs {
+		if tweets[i].ID != id {
+			t.Errorf("Expected tweet #%d ID %s, got %s", i, id, tweets[i].ID)
+		}
+		if tweets[i].IsPin != (i == 0) {
+			t.Errorf("Unexpected tweet #%d IsPin %v", i, tweets[i].IsPin)
+		}
+		if tweets[i].Username != "Twitter" {
+			t.Errorf("Unexpected tweet #%d Username %s", i, tweets[i].Username)
+		}
+	}
+
+	for _, req := range transport.Requests() {
+		if strings.HasSuffix(req.URL.Path, "/UserByScreenName") {
+			continue
+		}
+		if userID := twitterscraper.FixtureVariables(req)["userId"]; userID != "783214" {
+			t.Errorf("Expected userId 783214, got %v", userID)
+		}
+	}
+}
+
+func TestFetchTweetsCursorFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	tweets, cursor, err := scraper.FetchTweets("Twitter", 20, "tweets2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cursor != "tweets3" {
+		t.Errorf("Expected cursor tweets3, got %s", cursor)
+	}
+	if len(tweets) != 2 {
+		t.Fatalf("Expected 2 tweets, got %d", len(tweets))
+	}
+	if tweets[0].IsPin {
+		t.Error("Expected tweet outside of pin entry is not pinned")
+	}
+}
+
+func TestGetTweetsWithRepliesFixture(t *testing.T) {
+	scraper, transport := twitterscraper.NewFixtureScraper()
+	scraper.WithReplies(true)
+	var tweets []twitterscraper.Tweet
+	for tweet := range scraper.GetTweets(context.Background(), "Twitter", 10) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		tweets = append(tweets, tweet.Tweet)
+	}
+
+	if len(tweets) != 2 {
+		t.Fatalf("Expected 2 tweets, got %d", len(tweets))
+	}
+	if tweets[0].IsReply || tweets[0].ConversationID != tweets[0].ID {
+		t.Error("Expected first tweet is not a reply")
+	}
+	reply := tweets[1]
+	if !reply.IsReply {
+		t.Error("Expected tweet IsReply is true")
+	}
+	if reply.ReplyingTo != "1712290000000000002" {
+		t.Errorf("Expected ReplyingTo 1712290000000000002, got %s", reply.ReplyingTo)
+	}
+	if reply.ConversationID != "1712290000000000002" {
+		t.Errorf("Expected ConversationID 1712290000000000002, got %s", reply.ConversationID)
+	}
+
+	for _, req := range transport.Requests() {
+		if strings.HasSuffix(req.URL.Path, "/UserByScreenName") {
+			continue
+		}
+		if !strings.HasSuffix(req.URL.Path, "/UserTweetsAndReplies") {
+			t.Errorf("Expected UserTweetsAndReplies request, got %s", req.URL.Path)
+		}
+	}
+}
+
+func TestGetMediaTweetsFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	var tweets []twitterscraper.Tweet
+	for tweet := range scraper.GetMediaTweets(context.Background(), "Twitter", 10) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		tweets = append(tweets, tweet.Tweet)
+	}
+
+	expectedTypes := []string{"photo", "video", "animated_gif"}
+	if len(tweets) != len(expectedTypes) {
+		t.Fatalf("Expected %d tweets, got %d", len(expectedTypes), len(tweets))
+	}
+	for i, mediaType := range expectedTypes {
+		if len(tweets[i].Media) != 1 {
+			t.Fatalf("Expected tweet #%d has 1 media, got %d", i, len(tweets[i].Media))
+		}
+		media := tweets[i].Media[0]
+		if media.Type != mediaType {
+			t.Errorf("Expected tweet #%d media type %s, got %s", i, mediaType, media.Type)
+		}
+		if media.IDStr == "" || media.MediaURLHttps == "" {
+			t.Errorf("Expected tweet #%d media ID and URL are not empty", i)
+		}
+	}
+	if len(tweets[1].Media[0].VideoInfo.Variants) != 4 {
+		t.Errorf("Expected 4 video variants, got %d", len(tweets[1].Media[0].VideoInfo.Variants))
+	}
+}
+
+func TestGetLikedTweetsFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	for tweet := range scraper.GetLikedTweets(context.Background(), "Twitter", 10) {
+		if !errors.Is(tweet.Error, twitterscraper.ErrAuthRequired) {
+			t.Errorf("Expected ErrAuthRequired, got %v", tweet.Error)
+		}
+	}
+
+	scraper.WithCookie("auth_token=fixture; ct0=fixture").WithXCsrfToken("fixture")
+	var tweets []twitterscraper.Tweet
+	for tweet := range scraper.GetLikedTweets(context.Background(), "Twitter", 10) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		tweets = append(tweets, tweet.Tweet)
+	}
+	expectedAuthors := []string{"TwitterDev", "Support"}
+	if len(tweets) != len(expectedAuthors) {
+		t.Fatalf("Expected %d tweets, got %d", len(expectedAuthors), len(tweets))
+	}
+	for i, username := range expectedAuthors {
+		if tweets[i].Username != username {
+			t.Errorf("Expected tweet #%d Username %s, got %s", i, username, tweets[i].Username)
+		}
+	}
+
+	_, _, err := scraper.FetchLikedTweets("Twitter", 20, "private")
+	if !errors.Is(err, twitterscraper.ErrLikesPrivate) {
+		t.Errorf("Expected ErrLikesPrivate, got %v", err)
+	}
+
+	_, _, err = scraper.FetchLikedTweets("Twitter", 20, "ratelimit")
+	var apiErr twitterscraper.Err
+	if errors.Is(err, twitterscraper.ErrLikesPrivate) || !errors.As(err, &apiErr) || apiErr.Code != 88 {
+		t.Errorf("Expected rate limit error, got %v", err)
+	}
+}
+
+func TestRetweetFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	tweets, _, err := scraper.FetchTweets("Twitter", 20, "retweets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tweets) != 1 {
+		t.Fatalf("Expected 1 tweet, got %d", len(tweets))
+	}
+	retweet := tweets[0]
+	if !retweet.IsRetweet || retweet.ID != "1713100000000000002" || retweet.Username != "Twitter" {
+		t.Errorf("Unexpected retweet %s %s IsRetweet %v", retweet.ID, retweet.Username, retweet.IsRetweet)
+	}
+	original := retweet.RetweetedStatus
+	if original == nil {
+		t.Fatal("Expected RetweetedStatus is set")
+	}
+	if retweet.RetweetedStatusID != original.ID {
+		t.Errorf("Expected RetweetedStatusID %s, got %s", original.ID, retweet.RetweetedStatusID)
+	}
+	if original.IsRetweet || original.ID != "1713100000000000001" || original.Username != "TwitterDev" || original.Likes != 5000 {
+		t.Errorf("Unexpected original tweet %s %s %d", original.ID, original.Username, original.Likes)
+	}
+	if !strings.HasSuffix(original.Text, "#DevPortal") || len(original.Hashtags) != 1 {
+		t.Errorf("Expected full text of the original tweet, got %q", original.Text)
+	}
+
+	scraper.WithUnwrapRetweets(true)
+	tweets, _, err = scraper.FetchTweets("Twitter", 20, "retweets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tweets) != 1 || tweets[0].ID != original.ID || tweets[0].IsRetweet {
+		t.Errorf("Expected retweet is unwrapped into the original tweet, got %v", tweets)
+	}
+}
+
+func TestLegacyRetweetFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	scraper.WithCookie("auth_token=fixture; ct0=fixture").WithXCsrfToken("fixture")
+	tweets, _, err := scraper.FetchMentions(20, "retweets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tweets) != 1 {
+		t.Fatalf("Expected 1 tweet, got %d", len(tweets))
+	}
+	if !tweets[0].IsRetweet || tweets[0].RetweetedStatusID != "1713100000000000001" {
+		t.Errorf("Unexpected retweet %v %s", tweets[0].IsRetweet, tweets[0].RetweetedStatusID)
+	}
+	original := tweets[0].RetweetedStatus
+	if original == nil || original.ID != "1713100000000000001" || original.Username != "TwitterDev" || original.Likes != 5000 {
+		t.Errorf("Unexpected original tweet %v", original)
+	}
+}
+
+func TestTweetMetadataFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	tweets, _, err := scraper.FetchTweetsAndReplies("Twitter", 20, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tweets) != 2 {
+		t.Fatalf("Expected 2 tweets, got %d", len(tweets))
+	}
+	for _, tweet := range tweets {
+		if tweet.Views != 12345 || tweet.ViewsState != "EnabledWithCount" || tweet.Bookmarks != 10 || tweet.Lang != "en" {
+			t.Errorf("Unexpected views %d %s, bookmarks %d or language %s", tweet.Views, tweet.ViewsState, tweet.Bookmarks, tweet.Lang)
+		}
+		if tweet.Source != "Twitter Web App" || tweet.SourceURL != "https://mobile.twitter.com" {
+			t.Errorf("Unexpected source %s %s", tweet.Source, tweet.SourceURL)
+		}
+		if tweet.IsQuoted {
+			t.Error("Expected tweet is not quote")
+		}
+	}
+	own, reply := tweets[0], tweets[1]
+	if diff := cmp.Diff([]string{"Reply"}, own.LimitedActions); diff != "" {
+		t.Error("Resulting limited actions does not match the sample", diff)
+	}
+	if own.IsSensitiveEditable || !reply.IsSensitiveEditable {
+		t.Errorf("Expected only reply IsSensitiveEditable, got %v %v", own.IsSensitiveEditable, reply.IsSensitiveEditable)
+	}
+	if reply.LimitedActions != nil {
+		t.Errorf("Expected no limited actions of reply, got %v", reply.LimitedActions)
+	}
+}
+
+func TestLegacyTweetMetadataFixture(t *testing.T) {
+	scraper, _ := twitterscraper.NewFixtureScraper()
+	scraper.WithCookie("auth_token=fixture; ct0=fixture").WithXCsrfToken("fixture")
+	tweets, _, err := scraper.FetchMentions(20, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tweets) != 2 {
+		t.Fatalf("Expected 2 mentions, got %d", len(tweets))
+	}
+	for _, tweet := range tweets {
+		if tweet.Bookmarks != 10 || tweet.Lang != "en" || tweet.Source != "Twitter Web App" {
+			t.Errorf("Unexpected bookmarks %d, language %s or source %s", tweet.Bookmarks, tweet.Lang, tweet.Source)
+		}
+	}
+	if tweets[0].Views != 12345 || tweets[0].ViewsState != "EnabledWithCount" {
+		t.Errorf("Unexpected views %d %s", tweets[0].Views, tweets[0].ViewsState)
+	}
+	if tweets[1].Views != 0 || tweets[1].ViewsState != "Disabled" {
+		t.Errorf("Expected disabled views, got %d %s", tweets[1].Views, tweets[1].ViewsState)
+	}
+}
+
+func TestFetchTweetsAndRepliesContextFixture(t *testing.T) {
+	scraper, transport := twitterscraper.NewFixtureScraper()
+	tweets, cursor, err := scraper.FetchTweetsAndReplies("Twitter", 20, "context1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tweets) != 1 || tweets[0].ID != "1712270000000000005" {
+		t.Fatalf("Expected own tweet of the next page, got %v", tweets)
+	}
+	if cursor != "context3" {
+		t.Errorf("Expected cursor context3, got %s", cursor)
+	}
+
+	var lookups, pages int
+	for _, req := range transport.Requests() {
+		if strings.HasSuffix(req.URL.Path, "/UserByScreenName") {
+			lookups++
+		} else {
+			pages++
+		}
+	}
+	if lookups != 1 || pages != 2 {
+		t.Errorf("Expected 1 user lookup and 2 pages, got %d and %d", lookups, pages)
+	}
+}
+
+func TestGetTweetAndRepliesRecursiveFixture(t *testing.T) {
+	scraper, transport := twitterscraper.NewFixtureScraper()
+	tweets, users, err := scraper.GetTweetAndRepliesRecursive("1713200000000000002")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// ancestor of the top page, focal tweet and replies of the bottom pages in order,
+	// the deleted reply without tweet ID in the entry ID is skipped
+	expected := []string{"1713200000000000001", "1713200000000000002", "1713200000000000003", "1713200000000000004", "1713200000000000005"}
+	var ids []string
+	for _, tweet := range tweets {
+		ids = append(ids, tweet.ID)
+	}
+	if diff := cmp.Diff(expected, ids); diff != "" {
+		t.Error("Resulting conversation does not match the sample", diff)
+	}
+	if len(users) != 3 {
+		t.Errorf("Expected 3 users, got %d", len(users))
+	}
+
+	var cursors []string
+	for _, req := range transport.Requests() {
+		cursor, _ := twitterscraper.FixtureVariables(req)["cursor"].(string)
+		cursors = append(cursors, cursor)
+	}
+	if diff := cmp.Diff([]string{"", "top1", "bottom2", "bottom3"}, cursors); diff != "" {
+		t.Error("Expected each page is requested once", diff)
+	}
+}
@@ -3,37 +3,60 @@ package twitterscraper
 import "time"
 
 type (
-	// Video type.
-	Video struct {
-		ID      string
-		Preview string
-		URL     string
-	}
-
 	// Tweet type.
 	Tweet struct {
-		ID               string
-		EditIds          []string
-		Hashtags         []string
-		ReplyingTo       string
-		QuoteRetweetId   string
-		IsReply          bool
-		IsRetweet        bool
-		PermanentURL     string
-		Media            []Media
-		Card             Card
-		Mentions         map[string]string
-		Text             string
-		TimeParsed       time.Time
-		Timestamp        int64
-		UserID           string
-		Username         string
-		SensitiveContent bool
-		Likes            int
-		Retweets         int
-		QuoteRetweets    int
-		Replies          int
-		IsTombstone      bool
+		ID                  string
+		EditIds             []string
+		IsEditable          bool
+		EditableUntil       time.Time
+		EditsRemaining      int
+		Hashtags            []string
+		ReplyingTo          string
+		ConversationID      string
+		CommunityID         string
+		SpaceID             string
+		BroadcastID         string
+		QuoteRetweetId      string
+		QuotedStatus        *Tweet
+		IsPin               bool
+		IsReply             bool
+		IsQuoted            bool
+		IsRetweet           bool
+		RetweetedStatusID   string
+		RetweetedStatus     *Tweet
+		PermanentURL        string
+		Media               []Media
+		Photos              []Photo
+		Videos              []Video
+		GIFs                []AnimatedGIF
+		Card                Card
+		CommunityNote       *CommunityNote
+		Poll                *Poll
+		LinkPreview         *LinkPreview
+		Mentions            []Mention
+		URLs                []URL
+		Cashtags            []Cashtag
+		Text                string
+		ExpandedText        string
+		Segments            []TextSegment
+		TimeParsed          time.Time
+		Timestamp           int64
+		UserID              string
+		Username            string
+		SensitiveContent    bool
+		IsSensitiveEditable bool
+		LimitedActions      []string
+		Lang                string
+		Source              string
+		SourceURL           string
+		Likes               int
+		Retweets            int
+		QuoteRetweets       int
+		Replies             int
+		Bookmarks           int
+		Views               int
+		ViewsState          string
+		IsTombstone         bool
 	}
 
 	// ProfileResult of scrapping.
@@ -48,6 +71,12 @@ type (
 		Error error
 	}
 
+	// ListResult of scrapping.
+	ListResult struct {
+		List
+		Error error
+	}
+
 	legacyUser struct {
 		CreatedAt   string `json:"created_at"`
 		Description string `json:"description"`
@@ -113,23 +142,42 @@ type (
 	}
 
 	Media struct {
-		IDStr                    string `json:"id_str"`
-		MediaURLHttps            string `json:"media_url_https"`
-		ExtSensitiveMediaWarning struct {
-			AdultContent    bool `json:"adult_content"`
-			GraphicViolence bool `json:"graphic_violence"`
-			Other           bool `json:"other"`
-		} `json:"ext_sensitive_media_warning"`
+		IDStr                    string                `json:"id_str"`
+		MediaKey                 string                `json:"media_key"`
+		MediaURLHttps            string                `json:"media_url_https"`
+		ExtAltText               string                `json:"ext_alt_text"`
+		ExtSensitiveMediaWarning SensitiveMediaWarning `json:"ext_sensitive_media_warning"`
+		ExtMediaColor            struct {
+			Palette []struct {
+				Percentage float64 `json:"percentage"`
+				Rgb        struct {
+					Red   int `json:"red"`
+					Green int `json:"green"`
+					Blue  int `json:"blue"`
+				} `json:"rgb"`
+			} `json:"palette"`
+		} `json:"ext_media_color"`
+		OriginalInfo struct {
+			Width  int `json:"width"`
+			Height int `json:"height"`
+		} `json:"original_info"`
+		MediaStats struct {
+			ViewCount int `json:"viewCount"`
+		} `json:"mediaStats"`
 		Type      string `json:"type"`
 		URL       string `json:"url"`
 		VideoInfo struct {
-			Variants []struct {
-				Bitrate int    `json:"bitrate,omitempty"`
-				URL     string `json:"url"`
+			AspectRatio    []int `json:"aspect_ratio"`
+			DurationMillis int   `json:"duration_millis"`
+			Variants       []struct {
+				Bitrate     int    `json:"bitrate,omitempty"`
+				ContentType string `json:"content_type"`
+				URL         string `json:"url"`
 			} `json:"variants"`
 		} `json:"video_info"`
 	}
 
 	fetchProfileFunc func(query string, maxProfilesNbr int, cursor string) ([]*Profile, string, error)
 	fetchTweetFunc   func(query string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error)
+	fetchListFunc    func(query string, maxListsNbr int, cursor string) ([]*List, string, error)
 )
@@ -2,17 +2,60 @@ package twitterscraper
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
-	"regexp"
 	"strconv"
 	"time"
 )
 
-var (
-	reHashtag    = regexp.MustCompile(`\B(\#\S+\b)`)
-	reTwitterURL = regexp.MustCompile(`https:(\/\/t\.co\/([A-Za-z0-9]|[A-Za-z]){10})`)
-	reUsername   = regexp.MustCompile(`\B(\@\S{1,15}\b)`)
-)
+// features flags sent with every GraphQL request
+var graphQLFeatures = map[string]interface{}{
+	"responsive_web_graphql_exclude_directive_enabled":                        true,
+	"verified_phone_label_enabled":                                            false,
+	"responsive_web_home_pinned_timelines_enabled":                            true,
+	"creator_subscriptions_tweet_preview_api_enabled":                         true,
+	"responsive_web_graphql_timeline_navigation_enabled":                      true,
+	"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
+	"c9s_tweet_anatomy_moderator_badge_enabled":                               true,
+	"tweetypie_unmention_optimization_enabled":                                true,
+	"responsive_web_edit_tweet_api_enabled":                                   true,
+	"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
+	"view_counts_everywhere_api_enabled":                                      true,
+	"longform_notetweets_consumption_enabled":                                 true,
+	"responsive_web_twitter_article_tweet_consumption_enabled":                false,
+	"tweet_awards_web_tipping_enabled":                                        false,
+	"freedom_of_speech_not_reach_fetch_enabled":                               true,
+	"standardized_nudges_misinfo":                                             true,
+	"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
+	"longform_notetweets_rich_text_read_enabled":                              true,
+	"longform_notetweets_inline_media_enabled":                                true,
+	"responsive_web_media_download_video_enabled":                             false,
+	"responsive_web_enhance_cards_enabled":                                    false,
+}
+
+// newGraphQLRequest creates GET request for GraphQL operation (in the format `queryId/OperationName`)
+func (s *Scraper) newGraphQLRequest(operation string, variables map[string]interface{}) (*http.Request, error) {
+	req, err := http.NewRequest("GET", "https://twitter.com/i/api/graphql/"+operation, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	jsnVariables, err := json.Marshal(variables)
+	if err != nil {
+		return nil, err
+	}
+	jsnFeatures, err := json.Marshal(graphQLFeatures)
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	q.Add("variables", string(jsnVariables))
+	q.Add("features", string(jsnFeatures))
+	req.URL.RawQuery = q.Encode()
+
+	return req, nil
+}
 
 func (s *Scraper) newRequest(method string, url string) (*http.Request, error) {
 	req, err := http.NewRequest(method, url, nil)
@@ -45,7 +88,7 @@ func (s *Scraper) newRequest(method string, url string) (*http.Request, error) {
 	q.Add("send_error_codes", "true")
 	q.Add("simple_quoted_tweet", "true")
 	q.Add("include_tweet_replies", strconv.FormatBool(s.includeReplies))
-	q.Add("ext", "mediaStats,highlightedLabel,hasNftAvatar,voiceInfo,superFollowMetadata")
+	q.Add("ext", "mediaStats,highlightedLabel,hasNftAvatar,voiceInfo,superFollowMetadata,views")
 	req.URL.RawQuery = q.Encode()
 
 	return req, nil
@@ -57,6 +100,8 @@ func getUserTimeline(ctx context.Context, query string, maxProfilesNbr int, fetc
 		defer close(channel)
 		var nextCursor string
 		profilesNbr := 0
+		// the same user can be returned on several pages
+		seen := make(map[string]bool)
 		for profilesNbr < maxProfilesNbr {
 			select {
 			case <-ctx.Done():
@@ -65,7 +110,8 @@ func getUserTimeline(ctx context.Context, query string, maxProfilesNbr int, fetc
 			default:
 			}
 
-			profiles, next, err := fetchFunc(query, maxProfilesNbr, nextCursor)
+			cursor := nextCursor
+			profiles, next, err := fetchFunc(query, maxProfilesNbr, cursor)
 			if err != nil {
 				channel <- &ProfileResult{Error: err}
 				return
@@ -84,6 +130,10 @@ func getUserTimeline(ctx context.Context, query string, maxProfilesNbr int, fetc
 				}
 
 				if profilesNbr < maxProfilesNbr {
+					if seen[profile.UserID] {
+						continue
+					}
+					seen[profile.UserID] = true
 					nextCursor = next
 					channel <- &ProfileResult{Profile: *profile}
 				} else {
@@ -91,6 +141,12 @@ func getUserTimeline(ctx context.Context, query string, maxProfilesNbr int, fetc
 				}
 				profilesNbr++
 			}
+
+			// the last page has no cursor or repeats the current one
+			if next == "" || next == cursor {
+				break
+			}
+			nextCursor = next
 		}
 	}(query)
 	return channel
@@ -102,6 +158,8 @@ func getTweetTimeline(ctx context.Context, query string, maxTweetsNbr int, fetch
 		defer close(channel)
 		var nextCursor string
 		tweetsNbr := 0
+		// pinned tweet is returned again in its place on the timeline
+		seen := make(map[string]bool)
 		for tweetsNbr < maxTweetsNbr {
 			select {
 			case <-ctx.Done():
@@ -110,7 +168,8 @@ func getTweetTimeline(ctx context.Context, query string, maxTweetsNbr int, fetch
 			default:
 			}
 
-			tweets, next, err := fetchFunc(query, maxTweetsNbr, nextCursor)
+			cursor := nextCursor
+			tweets, next, err := fetchFunc(query, maxTweetsNbr, cursor)
 			if err != nil {
 				channel <- &TweetResult{Error: err}
 				return
@@ -132,6 +191,10 @@ func getTweetTimeline(ctx context.Context, query string, maxTweetsNbr int, fetch
 					if tweet.IsPin && nextCursor != "" {
 						continue
 					}
+					if seen[tweet.ID] {
+						continue
+					}
+					seen[tweet.ID] = true
 					nextCursor = next
 					channel <- &TweetResult{Tweet: *tweet}
 				} else {
@@ -139,6 +202,63 @@ func getTweetTimeline(ctx context.Context, query string, maxTweetsNbr int, fetch
 				}
 				tweetsNbr++
 			}
+
+			// the last page has no cursor or repeats the current one
+			if next == "" || next == cursor {
+				break
+			}
+			nextCursor = next
+		}
+	}(query)
+	return channel
+}
+
+func getListTimeline(ctx context.Context, query string, maxListsNbr int, fetchFunc fetchListFunc) <-chan *ListResult {
+	channel := make(chan *ListResult)
+	go func(query string) {
+		defer close(channel)
+		var nextCursor string
+		listsNbr := 0
+		for listsNbr < maxListsNbr {
+			select {
+			case <-ctx.Done():
+				channel <- &ListResult{Error: ctx.Err()}
+				return
+			default:
+			}
+
+			cursor := nextCursor
+			lists, next, err := fetchFunc(query, maxListsNbr, cursor)
+			if err != nil {
+				channel <- &ListResult{Error: err}
+				return
+			}
+
+			if len(lists) == 0 {
+				break
+			}
+
+			for _, list := range lists {
+				select {
+				case <-ctx.Done():
+					channel <- &ListResult{Error: ctx.Err()}
+					return
+				default:
+				}
+
+				if listsNbr < maxListsNbr {
+					channel <- &ListResult{List: *list}
+				} else {
+					break
+				}
+				listsNbr++
+			}
+
+			// the last page has no cursor or repeats the current one
+			if next == "" || next == cursor {
+				break
+			}
+			nextCursor = next
 		}
 	}(query)
 	return channel
@@ -159,7 +279,6 @@ func parseProfile(user UserResult) Profile {
 		ListedCount:      user.Legacy.ListedCount,
 		Location:         user.Legacy.Location,
 		Name:             user.Legacy.Name,
-		PinnedTweetID:    user.Legacy.PinnedTweetIdsStr[0],
 		TweetsCount:      user.Legacy.StatusesCount,
 		URL:              "https://twitter.com/" + user.Legacy.ScreenName,
 		UserID:           user.Legacy.IDStr,
@@ -175,6 +294,14 @@ func parseProfile(user UserResult) Profile {
 		profile.Joined = &tm
 	}
 
+	if profile.UserID == "" {
+		profile.UserID = user.RestId
+	}
+
+	if len(user.Legacy.PinnedTweetIdsStr) > 0 {
+		profile.PinnedTweetID = user.Legacy.PinnedTweetIdsStr[0]
+	}
+
 	if len(user.Legacy.Entities.URL.Urls) > 0 {
 		profile.Website = user.Legacy.Entities.URL.Urls[0].ExpandedURL
 	}